@@ -0,0 +1,52 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func runDeprecations(args []string) int {
+	flags := flag.NewFlagSet("deprecations", flag.ExitOnError)
+	maxReleases := flags.Int("max-releases", 0,
+		"warn when something has been deprecated for more than N releases (0 disables)")
+	flags.Parse(args)
+
+	filename, data, err := readInput(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	root, err := changelogyaml.ParseYamlNode(data)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	report := changelogyaml.CheckDeprecations(changelogyaml.ChangeNodes(root), *maxReleases)
+	for _, warning := range report.Warnings {
+		fmt.Printf("%s:%v\n", filename, warning)
+	}
+
+	if len(report.Pending) > 0 {
+		fmt.Printf("\nPending removal:\n")
+		for _, pending := range report.Pending {
+			fmt.Printf("  %s (%s) deprecated in %s, %d releases ago (line %d)\n", pending.Identifier,
+				pending.GroupKey, pending.ReleaseName, pending.ReleasesSince, pending.Line)
+		}
+	}
+
+	if len(report.Warnings) > 0 {
+		return exitIssues
+	}
+
+	return 0
+}
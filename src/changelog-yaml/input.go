@@ -0,0 +1,55 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+// readInput reads the file in the argument, or stdin if there are no arguments. The commands that use it report line
+// numbers, so only one file can be given.
+func readInput(args []string) (string, []byte, error) {
+	if len(args) == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		return "<stdin>", data, err
+	}
+
+	if len(args) > 1 {
+		return "", nil, fmt.Errorf("only one changelog file can be given, got %d", len(args))
+	}
+
+	data, err := os.ReadFile(args[0])
+
+	return args[0], data, err
+}
+
+// readChangelog loads the changelogs in the arguments, or from stdin if there are no arguments. The releases of all
+// files are added in order. Included files and repo changelogs are relative to the file, or to the current directory
+// for stdin.
+func readChangelog(args []string) (*changelogyaml.ChangelogYaml, error) {
+	if len(args) == 0 {
+		return changelogyaml.ReadChangelog(os.Stdin, ".")
+	}
+
+	c, err := changelogyaml.LoadChangelog(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filename := range args[1:] {
+		other, err := changelogyaml.LoadChangelog(filename)
+		if err != nil {
+			return nil, err
+		}
+		c.Merge(other)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,76 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func runLint(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	disable := flags.String("disable", "", "comma separated rules to disable: "+
+		strings.Join(changelogyaml.LintRules, ", "))
+	enable := flags.String("enable", "", "comma separated rules to check, all other rules are disabled")
+	requirePeriod := flags.Bool("require-period", false, "require a trailing period instead of forbidding it")
+	maxLength := flags.Int("max-length", 120, "maximum number of characters in an entry")
+	flags.Parse(args)
+
+	enabledRules := splitList(*enable)
+	disabledRules := splitList(*disable)
+	for _, rule := range append(append([]string(nil), enabledRules...), disabledRules...) {
+		if !changelogyaml.IsLintRule(rule) {
+			fmt.Fprintf(flags.Output(), "unknown lint rule '%s', supported are %s\n", rule,
+				strings.Join(changelogyaml.LintRules, ", "))
+			flags.Usage()
+			return exitError
+		}
+	}
+
+	config := changelogyaml.DefaultLintConfig()
+	config.RequirePeriod = *requirePeriod
+	config.MaxLength = *maxLength
+
+	if len(enabledRules) > 0 {
+		for _, rule := range changelogyaml.LintRules {
+			config.Disabled[rule] = true
+		}
+		for _, rule := range enabledRules {
+			config.Disabled[rule] = false
+		}
+	}
+
+	for _, rule := range disabledRules {
+		config.Disabled[rule] = true
+	}
+
+	filename, data, err := readInput(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	root, err := changelogyaml.ParseYamlNode(data)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	issues := changelogyaml.LintChanges(changelogyaml.ChangeNodes(root), config)
+	for _, issue := range issues {
+		fmt.Printf("%s:%v\n", filename, issue)
+	}
+
+	if len(issues) > 0 {
+		return exitIssues
+	}
+
+	return 0
+}
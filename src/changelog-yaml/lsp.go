@@ -0,0 +1,309 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+// A minimal Language Server Protocol server over stdio. Positions in the protocol are zero-based, and the characters
+// are treated as runes, which is the same as UTF-16 code units for everything except characters outside the BMP.
+
+type lspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *lspError        `json:"error,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspTextDocumentPositionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position lspPosition `json:"position"`
+}
+
+type lspDidOpenParams struct {
+	TextDocument struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	} `json:"textDocument"`
+}
+
+type lspDidChangeParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspServer struct {
+	reader    *bufio.Reader
+	writer    io.Writer
+	documents map[string][]byte
+}
+
+func (s *lspServer) readMessage() (*lspMessage, error) {
+	headers, err := textproto.NewReader(s.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("bad Content-Length: %w", err)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, body); err != nil {
+		return nil, err
+	}
+
+	var message lspMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+func (s *lspServer) writeMessage(message *lspMessage) error {
+	message.JSONRPC = "2.0"
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(body), body)
+
+	return err
+}
+
+func (s *lspServer) notify(method string, params interface{}) error {
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	return s.writeMessage(&lspMessage{Method: method, Params: encodedParams})
+}
+
+func (s *lspServer) publishDiagnostics(uri string) error {
+	type lspDiagnostic struct {
+		Range    lspRange `json:"range"`
+		Severity int      `json:"severity"`
+		Source   string   `json:"source"`
+		Message  string   `json:"message"`
+	}
+
+	diagnostics := []lspDiagnostic{}
+	for _, diagnostic := range changelogyaml.Diagnose(s.documents[uri]) {
+		start := lspPosition{Line: diagnostic.Line - 1, Character: diagnostic.Column - 1}
+		end := lspPosition{Line: start.Line, Character: start.Character + diagnostic.Length}
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    lspRange{Start: start, End: end},
+			Severity: int(diagnostic.Severity),
+			Source:   "changelog-yaml",
+			Message:  diagnostic.Message,
+		})
+	}
+
+	return s.notify("textDocument/publishDiagnostics", map[string]interface{}{
+		"uri":         uri,
+		"diagnostics": diagnostics,
+	})
+}
+
+func (s *lspServer) completion(params *lspTextDocumentPositionParams) interface{} {
+	type lspCompletionItem struct {
+		Label         string `json:"label"`
+		Kind          int    `json:"kind"`
+		Detail        string `json:"detail,omitempty"`
+		Documentation string `json:"documentation,omitempty"`
+	}
+
+	const propertyKind = 10
+
+	items := []lspCompletionItem{}
+	for _, completion := range changelogyaml.Completions(s.documents[params.TextDocument.URI],
+		params.Position.Line+1, params.Position.Character+1) {
+		items = append(items, lspCompletionItem{
+			Label:         completion.Label,
+			Kind:          propertyKind,
+			Detail:        completion.Detail,
+			Documentation: completion.Documentation,
+		})
+	}
+
+	return items
+}
+
+func (s *lspServer) hover(params *lspTextDocumentPositionParams) interface{} {
+	contents, found := changelogyaml.Hover(s.documents[params.TextDocument.URI], params.Position.Line+1)
+	if !found {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"contents": map[string]string{"kind": "markdown", "value": contents},
+	}
+}
+
+func (s *lspServer) definition(params *lspTextDocumentPositionParams) interface{} {
+	location, found := changelogyaml.Definition(s.documents[params.TextDocument.URI], params.Position.Line+1,
+		params.Position.Character+1)
+	if !found {
+		return nil
+	}
+
+	position := lspPosition{Line: location.Line - 1, Character: location.Column - 1}
+
+	return map[string]interface{}{
+		"uri":   params.TextDocument.URI,
+		"range": lspRange{Start: position, End: position},
+	}
+}
+
+// handle returns the result for requests. The bool is true when the server should exit.
+func (s *lspServer) handle(message *lspMessage) (interface{}, bool, error) {
+	switch message.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1,
+				"completionProvider": map[string]interface{}{},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "changelog-yaml"},
+		}, false, nil
+	case "textDocument/didOpen":
+		var params lspDidOpenParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, false, err
+		}
+		s.documents[params.TextDocument.URI] = []byte(params.TextDocument.Text)
+		return nil, false, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params lspDidChangeParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, false, err
+		}
+		if len(params.ContentChanges) > 0 {
+			s.documents[params.TextDocument.URI] = []byte(params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+		return nil, false, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params lspDidOpenParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, false, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, false, nil
+	case "textDocument/completion", "textDocument/hover", "textDocument/definition":
+		var params lspTextDocumentPositionParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, false, err
+		}
+		switch message.Method {
+		case "textDocument/completion":
+			return s.completion(&params), false, nil
+		case "textDocument/hover":
+			return s.hover(&params), false, nil
+		default:
+			return s.definition(&params), false, nil
+		}
+	case "exit":
+		return nil, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (s *lspServer) run() error {
+	for {
+		message, err := s.readMessage()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		result, shouldExit, err := s.handle(message)
+		if shouldExit {
+			return nil
+		}
+
+		if message.ID == nil {
+			if err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+
+		response := &lspMessage{ID: message.ID, Result: result}
+		if err != nil {
+			response.Error = &lspError{Code: -32603, Message: err.Error()}
+		} else if result == nil {
+			response.Result = json.RawMessage("null")
+		}
+
+		if err := s.writeMessage(response); err != nil {
+			return err
+		}
+	}
+}
+
+func runLSP(args []string) int {
+	if len(args) > 0 && !strings.HasPrefix(args[0], "--stdio") {
+		log.Printf("unknown argument '%s', only stdio is supported", args[0])
+		return exitError
+	}
+
+	server := lspServer{
+		reader:    bufio.NewReader(os.Stdin),
+		writer:    os.Stdout,
+		documents: make(map[string][]byte),
+	}
+
+	if err := server.run(); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return 0
+}
@@ -8,31 +8,325 @@ package main
 import (
 	"bufio"
 	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/piot/changelog-yaml/changelogyaml"
 )
 
+const (
+	// exitIssues is returned when a check, like lint, found problems in the changelog.
+	exitIssues = 1
+	// exitError is returned when the changelog could not be read or the output could not be written.
+	exitError = 2
+)
+
+func formatterFromName(outputFormat string, emojiStyle changelogyaml.EmojiStyle) changelogyaml.Formatter {
+	if outputFormat == "adoc" || outputFormat == "asciidoc" {
+		return &changelogyaml.AsciiDocFormatter{EmojiStyle: emojiStyle}
+	}
+
+	return &changelogyaml.MarkdownFormatter{EmojiStyle: emojiStyle}
+}
+
+// formatNameFromFilename returns the output format for the file extension, or defaultFormat if it is not known.
+func formatNameFromFilename(filename string, defaultFormat string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".md", ".markdown":
+		return "md"
+	case ".adoc", ".asciidoc":
+		return "adoc"
+	}
+
+	return defaultFormat
+}
+
+// parseIconStyles parses a style for all formats, e.g. `label`, or a comma separated list of formats and styles, e.g.
+// `md=badge,adoc=label`. The style for all formats has the key "".
+func parseIconStyles(value string) (map[string]changelogyaml.CategoryIconStyle, error) {
+	styles := map[string]changelogyaml.CategoryIconStyle{"": changelogyaml.EmojiIcons}
+
+	for _, part := range strings.Split(value, ",") {
+		format, name, hasFormat := strings.Cut(strings.TrimSpace(part), "=")
+		if !hasFormat {
+			format, name = "", format
+		}
+
+		style, err := changelogyaml.ParseCategoryIconStyle(name)
+		if err != nil {
+			return nil, err
+		}
+		styles[format] = style
+	}
+
+	return styles, nil
+}
+
+func iconStyleForFormat(styles map[string]changelogyaml.CategoryIconStyle,
+	outputFormat string) changelogyaml.CategoryIconStyle {
+	if outputFormat == "asciidoc" {
+		outputFormat = "adoc"
+	}
+
+	if style, found := styles[outputFormat]; found {
+		return style
+	}
+
+	return styles[""]
+}
+
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+type renderSettings struct {
+	templateText string
+	options      changelogyaml.RenderOptions
+}
+
+func render(c *changelogyaml.ChangelogYaml, formatter changelogyaml.Formatter, settings *renderSettings,
+	writer io.Writer) error {
+	if settings.templateText != "" {
+		return changelogyaml.WriteTemplateDocument(c, settings.templateText, formatter, writer)
+	}
+
+	return changelogyaml.WriteDocumentWithOptions(c, formatter, settings.options, writer)
+}
+
+func renderToFile(c *changelogyaml.ChangelogYaml, formatter changelogyaml.Formatter, settings *renderSettings,
+	filename string) error {
+	if filename == "-" {
+		writer := bufio.NewWriter(os.Stdout)
+		if err := render(c, formatter, settings, writer); err != nil {
+			return err
+		}
+		return writer.Flush()
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	if err := render(c, formatter, settings, writer); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// setFlagNames returns the names, of the ones given, that are set on the command line.
+func setFlagNames(flags *flag.FlagSet, names ...string) []string {
+	var found []string
+	flags.Visit(func(f *flag.Flag) {
+		for _, name := range names {
+			if f.Name == name {
+				found = append(found, name)
+			}
+		}
+	})
+
+	return found
+}
+
 func main() {
-	var outputFormat = flag.String("format", "md", "output format: md or adoc")
-	flag.Parse()
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "lint":
+			os.Exit(runLint(os.Args[2:]))
+		case "deprecations":
+			os.Exit(runDeprecations(os.Args[2:]))
+		case "upgrade-guide":
+			os.Exit(runUpgradeGuide(os.Args[2:]))
+		case "query":
+			os.Exit(runQuery(os.Args[2:]))
+		case "stats":
+			os.Exit(runStats(os.Args[2:]))
+		case "schema":
+			os.Exit(runSchema(os.Args[2:]))
+		case "verify":
+			os.Exit(runVerify(os.Args[2:]))
+		case "lsp":
+			os.Exit(runLSP(os.Args[2:]))
+		case "validate":
+			os.Exit(runValidate(os.Args[2:]))
+		}
+	}
 
-	var c changelogyaml.ChangelogYaml
+	os.Exit(runRender(os.Args[1:]))
+}
+
+func runRender(args []string) int {
+	flags := flag.NewFlagSet("changelog-yaml", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: changelog-yaml [flags] [--] [changelog.yaml ...]\n"+
+			"       changelog-yaml lint|validate|deprecations|upgrade-guide|query|stats|schema|verify|lsp [flags] "+
+			"[changelog.yaml]\n\n"+
+			"Use -- before a file that has the name of a command, e.g. changelog-yaml -- stats\n\n")
+		flags.PrintDefaults()
+	}
 
-	reader := bufio.NewReader(os.Stdin)
-	c.ReadYaml(reader)
+	var outputs stringList
+	flags.Var(&outputs, "o", "output file, can be repeated. The format is taken from the extension (.md or .adoc). "+
+		"Default is stdout")
+	var outputFormat = flags.String("format", "md",
+		"output format when it can not be taken from the extension: md or adoc")
+	var templateFilename = flags.String("template", "",
+		"render using a text/template file instead of the built-in layout")
+	var tableOfContents = flags.Bool("toc", false, "add a table of contents after the heading")
+	var maxReleases = flags.Int("max-releases", 0, "only write the newest N releases (0 writes all)")
+	var collapseAfter = flags.Int("collapse-after", 0, "collapse all releases after the newest N releases (0 disables)")
+	var archiveURL = flags.String("archive-url", "", "add a footer link to the full changelog")
+	var contributors = flags.Bool("contributors", false, "add a list of the users mentioned in each release")
+	var outDir = flags.String("out-dir", "", "write one file per release, and an index file, to this directory")
+	var frontMatterPreset = flags.String("front-matter", "none",
+		"front matter for -out-dir: none, hugo, jekyll, docusaurus or mkdocs")
+	var frontMatterTemplateFilename = flags.String("front-matter-template", "",
+		"text/template file with the front matter for -out-dir")
+	var indexName = flags.String("index-name", "index", "filename of the index file for -out-dir, without extension")
+	var emojiStyleName = flags.String("emoji", "shortcode",
+		"how emoji are written: shortcode (Unicode in AsciiDoc), unicode or none")
+	var iconStyleNames = flags.String("icons", "emoji",
+		"what is written in front of each entry: emoji, label, badge or none. "+
+			"Can be set per format, e.g. md=badge,adoc=label")
+	var dateFormat = flags.String("date-format", "",
+		"how release dates are written: iso, long, relative or a Go time layout. Default is as written in the file")
+	var dateLocale = flags.String("date-locale", "", "language of month and weekday names and relative dates: "+
+		strings.Join(changelogyaml.DateLocales(), ", "))
+	flags.Parse(args)
 
-	var formatter changelogyaml.Formatter
-	if *outputFormat == "adoc" || *outputFormat == "asciidoc" {
-		formatter = &changelogyaml.AsciiDocFormatter{}
-	} else {
-		formatter = &changelogyaml.MarkdownFormatter{}
+	emojiStyle, err := changelogyaml.ParseEmojiStyle(*emojiStyleName)
+	if err != nil {
+		log.Println(err)
+		return exitError
 	}
 
-	err := changelogyaml.WriteDocument(&c, formatter, os.Stdout)
+	iconStyles, err := parseIconStyles(*iconStyleNames)
 	if err != nil {
 		log.Println(err)
-		os.Exit(-2)
+		return exitError
+	}
+
+	c, err := readChangelog(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	if *outDir != "" {
+		if ignored := setFlagNames(flags, "o", "template", "toc", "collapse-after", "archive-url"); len(ignored) > 0 {
+			fmt.Fprintf(flags.Output(), "-%s can not be used together with -out-dir\n", ignored[0])
+			flags.Usage()
+			return exitError
+		}
+
+		formatter := formatterFromName(*outputFormat, emojiStyle)
+		extension := ".md"
+		if _, isAsciiDoc := formatter.(*changelogyaml.AsciiDocFormatter); isAsciiDoc {
+			extension = ".adoc"
+		}
+
+		options := changelogyaml.SplitOptions{
+			Extension: extension,
+			IndexName: *indexName,
+			RenderOptions: changelogyaml.RenderOptions{
+				MaxReleases:   *maxReleases,
+				Contributors:  *contributors,
+				CategoryIcons: iconStyleForFormat(iconStyles, *outputFormat),
+				DateFormat:    *dateFormat,
+				DateLocale:    *dateLocale,
+			},
+		}
+
+		if err := writeReleaseFiles(c, formatter, options, *outDir, *frontMatterPreset,
+			*frontMatterTemplateFilename); err != nil {
+			log.Println(err)
+			return exitError
+		}
+
+		return 0
 	}
+
+	settings := renderSettings{
+		options: changelogyaml.RenderOptions{
+			TableOfContents: *tableOfContents,
+			MaxReleases:     *maxReleases,
+			CollapseAfter:   *collapseAfter,
+			ArchiveURL:      *archiveURL,
+			Contributors:    *contributors,
+			DateFormat:      *dateFormat,
+			DateLocale:      *dateLocale,
+		},
+	}
+
+	if *templateFilename != "" {
+		templateText, err := os.ReadFile(*templateFilename)
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		settings.templateText = string(templateText)
+	}
+
+	if len(outputs) == 0 {
+		outputs = stringList{"-"}
+	}
+
+	for _, output := range outputs {
+		// Every output gets its own formatter, since formatters can keep state while rendering
+		formatName := formatNameFromFilename(output, *outputFormat)
+		formatter := formatterFromName(formatName, emojiStyle)
+		outputSettings := settings
+		outputSettings.options.CategoryIcons = iconStyleForFormat(iconStyles, formatName)
+		if err := renderToFile(c, formatter, &outputSettings, output); err != nil {
+			log.Println(err)
+			return exitError
+		}
+	}
+
+	return 0
+}
+
+func writeReleaseFiles(c *changelogyaml.ChangelogYaml, formatter changelogyaml.Formatter,
+	options changelogyaml.SplitOptions, directory string, frontMatterPreset string,
+	frontMatterTemplateFilename string) error {
+	frontMatterTemplate, err := changelogyaml.FrontMatterPreset(frontMatterPreset)
+	if err != nil {
+		return err
+	}
+
+	if frontMatterTemplateFilename != "" {
+		templateText, readErr := os.ReadFile(frontMatterTemplateFilename)
+		if readErr != nil {
+			return readErr
+		}
+		frontMatterTemplate = string(templateText)
+	}
+
+	document, err := changelogyaml.BuildDocument(c)
+	if err != nil {
+		return err
+	}
+
+	options.FrontMatterTemplate = frontMatterTemplate
+
+	return changelogyaml.WriteReleaseFiles(document, formatter, options, directory)
 }
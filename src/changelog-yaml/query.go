@@ -0,0 +1,77 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+
+	return result
+}
+
+func runQuery(args []string) int {
+	flags := flag.NewFlagSet("query", flag.ExitOnError)
+	outputFormat := flags.String("format", "md", "output format: md or adoc")
+	from := flags.String("from", "", "oldest release to include")
+	to := flags.String("to", "", "newest release to include")
+	since := flags.String("since", "", "only releases on or after this date, e.g. 2023-06-01")
+	categories := flags.String("category", "", "comma separated categories, e.g. breaking,removed")
+	repos := flags.String("repo", "", "comma separated repo keys")
+	sections := flags.String("section", "", "comma separated section names")
+	authors := flags.String("author", "", "comma separated users that are mentioned, e.g. @piot")
+	flags.Parse(args)
+
+	query := changelogyaml.Query{
+		From:     *from,
+		To:       *to,
+		Since:    *since,
+		Repos:    splitList(*repos),
+		Sections: splitList(*sections),
+		Authors:  splitList(*authors),
+	}
+
+	for _, name := range splitList(*categories) {
+		category, err := changelogyaml.ParseCategory(name)
+		if err != nil {
+			log.Println(err)
+			return exitError
+		}
+		query.Categories = append(query.Categories, category)
+	}
+
+	c, err := readChangelog(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	result, err := changelogyaml.QueryChangelog(c, query)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	formatter := formatterFromName(*outputFormat, changelogyaml.ShortcodeEmoji)
+	if err := changelogyaml.WriteDocument(result, formatter, os.Stdout); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return 0
+}
@@ -0,0 +1,22 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func runSchema(args []string) int {
+	if _, err := os.Stdout.Write(changelogyaml.JSONSchema()); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return 0
+}
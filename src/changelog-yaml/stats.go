@@ -0,0 +1,82 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func writeCountTable(writer io.Writer, title string, counts []changelogyaml.Count) {
+	if len(counts) == 0 {
+		return
+	}
+
+	fmt.Fprintf(writer, "%s\t\n", title)
+	for _, count := range counts {
+		fmt.Fprintf(writer, "  %s\t%d\n", count.Name, count.Count)
+	}
+	fmt.Fprintf(writer, "\t\n")
+}
+
+func writeStatisticsTable(writer io.Writer, statistics *changelogyaml.Statistics) error {
+	tableWriter := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tableWriter, "entries\t%d\n\t\n", statistics.Entries)
+
+	releases := make([]changelogyaml.Count, 0, len(statistics.Releases))
+	for _, release := range statistics.Releases {
+		releases = append(releases, changelogyaml.Count{Name: release.Name, Count: release.Entries})
+	}
+
+	writeCountTable(tableWriter, "categories", statistics.Categories)
+	writeCountTable(tableWriter, "repos", statistics.Repos)
+	writeCountTable(tableWriter, "releases", releases)
+	writeCountTable(tableWriter, "contributors", statistics.Contributors)
+
+	return tableWriter.Flush()
+}
+
+func runStats(args []string) int {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	outputJSON := flags.Bool("json", false, "write JSON instead of a table")
+	flags.Parse(args)
+
+	c, err := readChangelog(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	document, err := changelogyaml.BuildDocument(c)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	statistics := changelogyaml.CollectStatistics(document)
+
+	if *outputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(statistics)
+	} else {
+		err = writeStatisticsTable(os.Stdout, &statistics)
+	}
+
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return 0
+}
@@ -0,0 +1,42 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func runUpgradeGuide(args []string) int {
+	flags := flag.NewFlagSet("upgrade-guide", flag.ExitOnError)
+	outputFormat := flags.String("format", "md", "output format: md or adoc")
+	from := flags.String("from", "", "the release that is upgraded from (default is the oldest release)")
+	to := flags.String("to", "", "the release that is upgraded to (default is the newest release)")
+	flags.Parse(args)
+
+	c, err := readChangelog(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	guide, err := changelogyaml.BuildUpgradeGuide(c, *from, *to)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	formatter := formatterFromName(*outputFormat, changelogyaml.ShortcodeEmoji)
+	if err := changelogyaml.RenderUpgradeGuide(guide, formatter, os.Stdout); err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	return 0
+}
@@ -0,0 +1,36 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func runValidate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	flags.Parse(args)
+
+	filename, data, err := readInput(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	diagnostics := changelogyaml.Diagnose(data)
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s:%v\n", filename, diagnostic)
+	}
+
+	if len(diagnostics) > 0 {
+		return exitIssues
+	}
+
+	return 0
+}
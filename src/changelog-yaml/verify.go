@@ -0,0 +1,60 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/piot/changelog-yaml/changelogyaml"
+)
+
+func runVerify(args []string) int {
+	config := changelogyaml.DefaultVerifyConfig()
+
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	gitDir := flags.String("git-dir", ".", "the git repository to verify against")
+	flags.StringVar(&config.RepoKey, "repo", "",
+		"verify the commit hashes of this repo key instead of the sections")
+	flags.IntVar(&config.MaxDateDifferenceDays, "max-date-difference", config.MaxDateDifferenceDays,
+		"how many days a tag's commit date may differ from the release date")
+	flags.Parse(args)
+
+	filename, data, err := readInput(flags.Args())
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	root, err := changelogyaml.ParseYamlNode(data)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	repository, err := changelogyaml.NewLocalGitRepository(*gitDir)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	diagnostics, err := changelogyaml.Verify(root, repository, config)
+	if err != nil {
+		log.Println(err)
+		return exitError
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s:%v\n", filename, diagnostic)
+	}
+
+	if len(diagnostics) > 0 {
+		return exitIssues
+	}
+
+	return 0
+}
@@ -8,7 +8,6 @@ package changelogyaml
 import (
 	"fmt"
 	"regexp"
-	"strings"
 )
 
 func stringToAdmonitionType(name string) AdmonitionType {
@@ -24,28 +23,22 @@ func stringToAdmonitionType(name string) AdmonitionType {
 	panic(fmt.Errorf("unknown admonition: '%s'", name))
 }
 
-func replaceAdmonition(line string, formatter Formatter) string {
-	re := regexp.MustCompile(`(WARNING|TIP|NOTE|IMPORTANT|CAUTION):\s.*`)
-	allMatches := re.FindAllStringIndex(line, -1)
+// parseAdmonition splits a notice into text and admonitions. An admonition continues to the end of the line. Other
+// types, like `TIP:`, are kept as text.
+func parseAdmonition(notice string) []InlineNode {
+	re := regexp.MustCompile(`(WARNING|NOTE|IMPORTANT):\s(.*)`)
+	allMatches := re.FindAllStringSubmatchIndex(notice, -1)
 
-	lineToPrint := ""
+	var nodes []InlineNode
 	previousMatchPosition := 0
 
-	if len(allMatches) > 0 {
-		for _, match := range allMatches {
-			matchString := line[match[0]:match[1]]
-
-			parts := strings.Split(matchString, ":")
-
-			lineToPrint += line[previousMatchPosition:match[0]] + formatter.Admonition(stringToAdmonitionType(parts[0]),
-				parts[1][1:])
-			previousMatchPosition = match[1]
-		}
-
-		lineToPrint += line[previousMatchPosition:]
-	} else {
-		lineToPrint = line
+	for _, match := range allMatches {
+		admonitionType := stringToAdmonitionType(notice[match[2]:match[3]])
+		nodes = append(nodes, parseInlineMarkup(notice[previousMatchPosition:match[0]])...)
+		nodes = append(nodes, AdmonitionNode{Type: admonitionType,
+			Children: parseInlineMarkup(notice[match[4]:match[5]])})
+		previousMatchPosition = match[1]
 	}
 
-	return lineToPrint
+	return append(nodes, parseInlineMarkup(notice[previousMatchPosition:])...)
 }
@@ -0,0 +1,34 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"testing"
+)
+
+func TestAdmonitions(t *testing.T) {
+	tests := []struct {
+		notice   string
+		markdown string
+		asciiDoc string
+	}{
+		{"NOTE: Read it", "> [!NOTE]\\\n> Read it", "NOTE: Read it"},
+		{"IMPORTANT: Read it", "> [!IMPORTANT]\\\n> Read it", "IMPORTANT: Read it"},
+		{"WARNING: Read it", "> [!WARNING]\\\n> Read it", "WARNING: Read it"},
+		{"TIP: Read it", "TIP: Read it", "TIP: Read it"},
+		{"CAUTION: Read it", "CAUTION: Read it", "CAUTION: Read it"},
+	}
+
+	for _, test := range tests {
+		inlines := parseAdmonition(test.notice)
+		if got := RenderInlines(inlines, &MarkdownFormatter{}); got != test.markdown {
+			t.Errorf("%q: got %q, want %q", test.notice, got, test.markdown)
+		}
+		if got := RenderInlines(inlines, &AsciiDocFormatter{}); got != test.asciiDoc {
+			t.Errorf("%q: got %q, want %q", test.notice, got, test.asciiDoc)
+		}
+	}
+}
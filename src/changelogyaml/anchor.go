@@ -0,0 +1,62 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// anchorID converts a name to a stable anchor ID, e.g. `v0.0.1-a06` to `v0-0-1-a06`. Only lower case letters and
+// digits are kept, everything else is collapsed to a single `-`. AsciiDoc IDs must start with a letter or `_`, so
+// `1.2.0` gets `_1-2-0`.
+func anchorID(name string) string {
+	var builder strings.Builder
+
+	previousWasSeparator := true
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			builder.WriteRune(r)
+			previousWasSeparator = false
+		} else if !previousWasSeparator {
+			builder.WriteRune('-')
+			previousWasSeparator = true
+		}
+	}
+
+	id := strings.TrimSuffix(builder.String(), "-")
+	if id != "" && !unicode.IsLetter(rune(id[0])) {
+		id = "_" + id
+	}
+
+	return id
+}
+
+// anchorIDs makes sure that every anchor ID in a document is unique, by adding a counter to duplicates.
+type anchorIDs struct {
+	used map[string]bool
+}
+
+func (a *anchorIDs) unique(name string) string {
+	if a.used == nil {
+		a.used = make(map[string]bool)
+	}
+
+	id := anchorID(name)
+	if id == "" {
+		id = "section"
+	}
+
+	candidate := id
+	for counter := 2; a.used[candidate]; counter++ {
+		candidate = fmt.Sprintf("%s-%d", id, counter)
+	}
+
+	a.used[candidate] = true
+
+	return candidate
+}
@@ -0,0 +1,119 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAnchorID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"v0.0.1-a06", "v0-0-1-a06"},
+		{"Unreleased", "unreleased"},
+		{"1.2.0", "_1-2-0"},
+		{"  Big  Release! ", "big-release"},
+		{"2023-06-22", "_2023-06-22"},
+		{"åäö", ""},
+	}
+
+	for _, test := range tests {
+		if got := anchorID(test.name); got != test.want {
+			t.Errorf("%q: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAnchorIDsUnique(t *testing.T) {
+	var ids anchorIDs
+
+	want := []string{"v1-0", "v1-0-2", "v1-0-3", "section", "section-2", "_1-0", "_1-0-2"}
+	for index, name := range []string{"v1.0", "v1 0", "V1-0", "", "!!", "1.0", "1-0"} {
+		if got := ids.unique(name); got != want[index] {
+			t.Errorf("%q: got %q, want %q", name, got, want[index])
+		}
+	}
+}
+
+const anchorTestChangelog = `
+repo: piot/nimble
+repos:
+  clog:
+    repo: piot/clog
+releases:
+  - name: 1.2.0
+    date: 2023-06-22
+    sections:
+      main:
+        changes:
+          fixed:
+            - Fix it
+    repos:
+      clog:
+        fixed:
+          - Fix clog
+  - name: 1.2.0
+    date: 2023-06-01
+`
+
+func TestTableOfContents(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		toc       string
+		links     *regexp.Regexp
+		anchors   *regexp.Regexp
+	}{
+		{"markdown", &MarkdownFormatter{},
+			"* [1.2.0](#_1-2-0)\n  * [main](#_1-2-0-main)\n  * [clog](#_1-2-0-clog)\n* [1.2.0](#_1-2-0-2)\n",
+			regexp.MustCompile(`\]\(#([^)]+)\)`), regexp.MustCompile(`<a id="([^"]+)"></a>`)},
+		{"asciidoc", &AsciiDocFormatter{},
+			"* <<_1-2-0,1.2.0>>\n** <<_1-2-0-main,main>>\n** <<_1-2-0-clog,clog>>\n* <<_1-2-0-2,1.2.0>>\n",
+			regexp.MustCompile(`<<([^,]+),`), regexp.MustCompile(`(?m)^\[\[([^\]]+)\]\]$`)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := ReadChangelog(bytes.NewBufferString(anchorTestChangelog), ".")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var output bytes.Buffer
+			if err := WriteDocumentWithOptions(root, test.formatter, RenderOptions{TableOfContents: true},
+				&output); err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(output.String(), test.toc) {
+				t.Errorf("table of contents is missing, want\n%s\ngot\n%s", test.toc, output.String())
+			}
+
+			anchors := make(map[string]bool)
+			for _, match := range test.anchors.FindAllStringSubmatch(output.String(), -1) {
+				if anchors[match[1]] {
+					t.Errorf("anchor '%s' is not unique", match[1])
+				}
+				anchors[match[1]] = true
+			}
+
+			links := test.links.FindAllStringSubmatch(output.String(), -1)
+			if len(links) != 4 || len(anchors) != 4 {
+				t.Errorf("got %d links and %d anchors, want 4 of each", len(links), len(anchors))
+			}
+			for _, match := range links {
+				if !anchors[match[1]] {
+					t.Errorf("link to '%s' has no anchor", match[1])
+				}
+			}
+		})
+	}
+}
@@ -10,24 +10,138 @@ import (
 	"strings"
 )
 
+// AsciiDocFormatter keeps track of if it is within a collapsible block, since sections are not allowed within blocks
+// and the headings must be discrete there.
 type AsciiDocFormatter struct {
+	// EmojiStyle is either NoEmoji or one that writes emoji as character references. AsciiDoc has no shortcodes.
+	EmojiStyle        EmojiStyle
+	insideCollapsible bool
 }
 
+// asciiDocTextEscaper replaces the characters that can start inline formatting, macros, attribute references or
+// cross references with character references.
+var asciiDocTextEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	`*`, `&#42;`,
+	`_`, `&#95;`,
+	"`", `&#96;`,
+	`#`, `&#35;`,
+	`^`, `&#94;`,
+	`~`, `&#126;`,
+	`+`, `&#43;`,
+	`[`, `&#91;`,
+	`]`, `&#93;`,
+	`{`, `&#123;`,
+	`}`, `&#125;`,
+	`\`, `&#92;`,
+)
+
+var asciiDocURLEscaper = strings.NewReplacer(
+	` `, `%20`,
+	`[`, `%5B`,
+	`]`, `%5D`,
+)
+
 func (a *AsciiDocFormatter) Heading(level int, header string) string {
-	return strings.Repeat("=", level) + " " + header + "\n\n"
+	discrete := ""
+	if a.insideCollapsible {
+		discrete = "[discrete]\n"
+	}
+
+	return discrete + strings.Repeat("=", level) + " " + header + "\n\n"
+}
+
+func (m *AsciiDocFormatter) Text(text string) string {
+	return asciiDocTextEscaper.Replace(text)
+}
+
+func (m *AsciiDocFormatter) Verbatim(text string) string {
+	return text
+}
+
+func (m *AsciiDocFormatter) Code(code string) string {
+	if strings.ContainsAny(code, "+`") {
+		return "`" + m.Text(code) + "`"
+	}
+
+	// The passthrough only applies the special characters substitution, so no formatting is done within the code.
+	return "`+" + code + "+`"
 }
 
 func (m *AsciiDocFormatter) BulletPoint(text string) string {
 	return "* " + text + "\n"
 }
 
+func (m *AsciiDocFormatter) ListItem(depth int, text string) string {
+	return strings.Repeat("*", depth+1) + " " + text + "\n"
+}
+
+func (m *AsciiDocFormatter) Anchor(id string) string {
+	return fmt.Sprintf("[[%s]]\n", id)
+}
+
+func (m *AsciiDocFormatter) InternalLink(name string, id string) string {
+	return fmt.Sprintf("<<%s,%s>>", id, m.Text(name))
+}
+
+func (m *AsciiDocFormatter) BeginCollapsible(summary string) string {
+	m.insideCollapsible = true
+	return fmt.Sprintf(".%s\n[%%collapsible]\n====\n", summary)
+}
+
+func (m *AsciiDocFormatter) EndCollapsible() string {
+	m.insideCollapsible = false
+	return "====\n\n"
+}
+
 func (m *AsciiDocFormatter) Emoji(name string) string {
-	unicodeInt := emojiNameToUnicode(name)
-	return fmt.Sprintf("&#x%X;", unicodeInt)
+	if m.EmojiStyle == NoEmoji {
+		return ""
+	}
+
+	unicode, found := EmojiUnicode(name)
+	if !found {
+		return m.Text(":" + name + ":")
+	}
+
+	var references strings.Builder
+	for _, codePoint := range unicode {
+		fmt.Fprintf(&references, "&#x%X;", codePoint)
+	}
+
+	return references.String()
 }
 
 func (m *AsciiDocFormatter) Link(name string, link string) string {
-	return fmt.Sprintf("%s[%s]", link, name)
+	return fmt.Sprintf("link:%s[%s]", asciiDocURLEscaper.Replace(link), name)
+}
+
+func (m *AsciiDocFormatter) Image(alt string, url string) string {
+	return fmt.Sprintf("image:%s[%s]", asciiDocURLEscaper.Replace(url), m.Text(alt))
+}
+
+func (m *AsciiDocFormatter) CodeBlock(language string, code string) string {
+	delimiter := "----"
+	for strings.Contains(code, delimiter) {
+		delimiter += "-"
+	}
+
+	attributes := "[source]"
+	if language != "" {
+		attributes = fmt.Sprintf("[source,%s]", language)
+	}
+
+	return attributes + "\n" + delimiter + "\n" + strings.TrimRight(code, "\n") + "\n" + delimiter + "\n\n"
+}
+
+func (m *AsciiDocFormatter) Strong(text string) string {
+	return "**" + text + "**"
+}
+
+func (m *AsciiDocFormatter) Emphasis(text string) string {
+	return "__" + text + "__"
 }
 
 func AdmonitionTypeToAsciiDocName(admonitionType AdmonitionType) string {
@@ -0,0 +1,150 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "time"
+
+// InlineNode is a piece of text within an entry or notice. It is one of TextNode, CodeNode, LinkNode, EmojiNode,
+// StrongNode, EmphasisNode or AdmonitionNode.
+type InlineNode interface {
+	inlineNode()
+}
+
+// TextNode is plain text. It has not been escaped for any output format.
+type TextNode struct {
+	Text string
+	// Verbatim text, like bare URLs and email addresses, is never changed by the autolinkers and is written without
+	// escaping.
+	Verbatim bool
+	// Escaped text is a reference that was written with a backslash, e.g. `\#12`. It is not autolinked, but is
+	// escaped as any other text.
+	Escaped bool
+}
+
+// CodeNode is an inline code span, written with backticks in the YAML file.
+type CodeNode struct {
+	Code string
+}
+
+// LinkNode is a link, either written as [text](url) or produced by one of the autolinkers.
+type LinkNode struct {
+	Kind LinkKind
+	Name string
+	URL  string
+	// Children is the link text with its inline markup, for links written as [text](url).
+	Children []InlineNode
+}
+
+// LinkKind tells which built-in autolinker that created a LinkNode.
+type LinkKind uint8
+
+const (
+	// MarkupLink is written as [text](url).
+	MarkupLink LinkKind = iota
+	PullRequestLink
+	// IssueLink is a `GH-123` reference, or a `#123` reference in a repo where they refer to issues.
+	IssueLink
+	CommitHashLink
+	// ProfileLink is a `@username` mention, the Name is the username with the `@`.
+	ProfileLink
+	// RuleLink is created by an AutolinkRule in the config.
+	RuleLink
+)
+
+// EmojiNode is an emoji, written as a `:shortcode:` in the YAML file. Name is the shortcode without the colons.
+type EmojiNode struct {
+	Name string
+}
+
+// StrongNode is bold text, written as **text**.
+type StrongNode struct {
+	Children []InlineNode
+}
+
+// EmphasisNode is italic text, written as _text_ or *text*.
+type EmphasisNode struct {
+	Children []InlineNode
+}
+
+// AdmonitionNode is a `NOTE: text` style admonition. It extends to the end of the line.
+type AdmonitionNode struct {
+	Type     AdmonitionType
+	Children []InlineNode
+}
+
+func (TextNode) inlineNode()       {}
+func (CodeNode) inlineNode()       {}
+func (LinkNode) inlineNode()       {}
+func (EmojiNode) inlineNode()      {}
+func (StrongNode) inlineNode()     {}
+func (EmphasisNode) inlineNode()   {}
+func (AdmonitionNode) inlineNode() {}
+
+// EntryNode is a single changelog line.
+type EntryNode struct {
+	Category CategoryType
+	// Raw is the text exactly as written in the YAML file.
+	Raw     string
+	Inlines []InlineNode
+	// Migration is optional and only rendered in the upgrade guide.
+	Migration *Migration
+}
+
+type GroupKind uint8
+
+const (
+	SectionGroup GroupKind = iota
+	RepoGroup
+)
+
+// GroupNode is either a section or a repo within a release.
+type GroupNode struct {
+	Kind GroupKind
+	// ID is the stable anchor ID, the release ID followed by the key.
+	ID string
+	// Key is the section name or the repo key used in the YAML file.
+	Key string
+	// Name is the display name. For repos it is the name from the repo definition, if set.
+	Name        string
+	Repo        string
+	URL         string
+	Description string
+	Notice      []InlineNode
+	// Entries are sorted by category, in the same order for every group.
+	Entries []EntryNode
+}
+
+type ReleaseNode struct {
+	// ID is the stable anchor ID, derived from the name. `v0.0.1-a06` has the ID `v0-0-1-a06`.
+	ID       string
+	Name     string
+	Codename string
+	// Date is as written in the YAML file, and Time is the parsed date. Both are zero for an unreleased release
+	// without a date.
+	Date string
+	Time time.Time
+	// URL is the release page of the tag, or the comparison with the previous release if it is unreleased.
+	URL          string
+	Prerelease   bool
+	Yanked       bool
+	YankedReason string
+	Links        []ReleaseLink
+	Assets       []ReleaseAsset
+	Notice       []InlineNode
+	// Groups has all sections, ordered by Order, followed by all repos sorted by key.
+	Groups []GroupNode
+}
+
+// DocumentNode is the root of the document tree that is built from a ChangelogYaml and consumed by the formatters.
+type DocumentNode struct {
+	Title    string
+	Repo     string
+	URL      string
+	Releases []ReleaseNode
+	// Categories has the info of each category, with the overrides in the changelog applied. Categories that are
+	// missing use the default info.
+	Categories map[CategoryType]CategoryInfo
+}
@@ -0,0 +1,135 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+)
+
+// AutolinkRule links every match of Pattern, e.g. Jira or Linear issue keys.
+type AutolinkRule struct {
+	// Pattern is a regular expression, e.g. `PROJ-\d+`. Where it starts or ends with a word character, it only matches
+	// whole words.
+	Pattern string
+	// URL is the link. `{0}` is replaced with the match and `{1}`, `{2}`... with the groups in Pattern,
+	// e.g. `https://jira.example.com/browse/{0}`.
+	URL string `yaml:"url"`
+}
+
+type compiledAutolinkRule struct {
+	re  *regexp.Regexp
+	url string
+}
+
+func compileAutolinkRules(rules []AutolinkRule) ([]compiledAutolinkRule, error) {
+	var compiled []compiledAutolinkRule
+
+	for _, rule := range rules {
+		if rule.Pattern == "" || rule.URL == "" {
+			return nil, fmt.Errorf("autolink rule must have both pattern and url")
+		}
+
+		parsed, err := syntax.Parse(rule.Pattern, syntax.Perl)
+		if err != nil {
+			return nil, fmt.Errorf("autolink pattern '%s': %w", rule.Pattern, err)
+		}
+
+		// Word boundaries are only added next to word characters, so patterns like `#\d+` still match
+		pattern := `(?:` + rule.Pattern + `)`
+		if isWordEdge(parsed, true) {
+			pattern = `\b` + pattern
+		}
+		if isWordEdge(parsed, false) {
+			pattern += `\b`
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("autolink pattern '%s': %w", rule.Pattern, err)
+		}
+
+		compiled = append(compiled, compiledAutolinkRule{re: re, url: rule.URL})
+	}
+
+	return compiled, nil
+}
+
+func isWordCharacter(r rune) bool {
+	return isWordRange(r, r)
+}
+
+// isWordRange returns true if all runes from low to high are word characters, as in `\w`.
+func isWordRange(low rune, high rune) bool {
+	return (low >= '0' && high <= '9') || (low >= 'A' && high <= 'Z') || (low >= 'a' && high <= 'z') ||
+		(low == '_' && high == '_')
+}
+
+// isWordEdge returns true if every match of the pattern starts (or ends, if first is false) with a word character.
+func isWordEdge(re *syntax.Regexp, first bool) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return false
+		}
+		if first {
+			return isWordCharacter(re.Rune[0])
+		}
+		return isWordCharacter(re.Rune[len(re.Rune)-1])
+	case syntax.OpCharClass:
+		if len(re.Rune) == 0 {
+			return false
+		}
+		for index := 0; index+1 < len(re.Rune); index += 2 {
+			if !isWordRange(re.Rune[index], re.Rune[index+1]) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpCapture, syntax.OpPlus:
+		return isWordEdge(re.Sub[0], first)
+	case syntax.OpRepeat:
+		return re.Min > 0 && isWordEdge(re.Sub[0], first)
+	case syntax.OpConcat:
+		if len(re.Sub) == 0 {
+			return false
+		}
+		if first {
+			return isWordEdge(re.Sub[0], first)
+		}
+		return isWordEdge(re.Sub[len(re.Sub)-1], first)
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if !isWordEdge(sub, first) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func (r *compiledAutolinkRule) Pattern() *regexp.Regexp {
+	return r.re
+}
+
+func (r *compiledAutolinkRule) Link(submatches []string, _ *LinkContext) (InlineNode, error) {
+	// `GH-123` is always a GitHub issue, even if a rule like `[A-Z]+-\d+` matches it
+	if issueReferenceRegexp.FindString(submatches[0]) == submatches[0] {
+		return nil, nil
+	}
+
+	url := r.url
+	for index, group := range submatches {
+		url = strings.ReplaceAll(url, "{"+strconv.Itoa(index)+"}", group)
+	}
+
+	return LinkNode{Kind: RuleLink, Name: submatches[0], URL: url}, nil
+}
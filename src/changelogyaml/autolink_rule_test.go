@@ -0,0 +1,57 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "testing"
+
+func TestAutolinkRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule AutolinkRule
+		line string
+		want string
+	}{
+		{"jira key", AutolinkRule{Pattern: `PROJ-\d+`, URL: "https://jira.example.com/browse/{0}"},
+			"Fix PROJ-12", "Fix [PROJ-12](https://jira.example.com/browse/PROJ-12)"},
+		{"only whole words", AutolinkRule{Pattern: `PROJ-\d+`, URL: "https://jira.example.com/browse/{0}"},
+			"Fix XPROJ-12 and PROJ-12a", "Fix XPROJ-12 and PROJ-12a"},
+		{"groups", AutolinkRule{Pattern: `([A-Z]+)-(\d+)`, URL: "https://linear.app/{1}/{2}"},
+			"Fix ENG-4", "Fix [ENG-4](https://linear.app/ENG/4)"},
+		{"GH is kept for issues", AutolinkRule{Pattern: `[A-Z]+-\d+`, URL: "https://jira.example.com/browse/{0}"},
+			"Fix GH-123", "Fix [GH-123](https://github.com/piot/nimble/issues/123)"},
+		{"starts with non-word character", AutolinkRule{Pattern: `#\d+`, URL: "https://tracker.example.com/{0}"},
+			"Fix #12", "Fix [#12](https://tracker.example.com/#12)"},
+		{"ends with non-word character", AutolinkRule{Pattern: `!\d+!`, URL: "https://tracker.example.com/{0}"},
+			"See !12!", "See [!12!](https://tracker.example.com/!12!)"},
+		{"word end after non-word start", AutolinkRule{Pattern: `#\d+`, URL: "https://tracker.example.com/{0}"},
+			"Fix #12a", "Fix #12a"},
+		{"alternation", AutolinkRule{Pattern: `(?:ABC|XYZ)-\d+`, URL: "https://tracker.example.com/{0}"},
+			"Fix XABC-1 and XYZ-2", "Fix XABC-1 and [XYZ-2](https://tracker.example.com/XYZ-2)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, err := compileAutolinkRules([]AutolinkRule{test.rule})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			context := testLinkContext()
+			context.rules = rules
+			if got := renderLine(t, test.line, context); got != test.want {
+				t.Errorf("%q: got %q, want %q", test.line, got, test.want)
+			}
+		})
+	}
+}
+
+func TestAutolinkRuleErrors(t *testing.T) {
+	for _, rule := range []AutolinkRule{{Pattern: `PROJ-\d+`}, {URL: "https://example.com"}, {Pattern: `(`, URL: "x"}} {
+		if _, err := compileAutolinkRules([]AutolinkRule{rule}); err == nil {
+			t.Errorf("%+v: expected an error", rule)
+		}
+	}
+}
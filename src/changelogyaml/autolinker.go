@@ -0,0 +1,151 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"regexp"
+	"sync"
+)
+
+// Autolinker turns references in the text of an entry, like `#123`, into links, or `:shortcode:` into emoji.
+//
+// Autolinkers only see the plain text of the tokenized line. Code spans, links (also those created by earlier
+// autolinkers), bare URLs, email addresses and escaped references like `\#1` are never passed to them.
+type Autolinker interface {
+	// Pattern matches the references in the text.
+	Pattern() *regexp.Regexp
+	// Link returns the node, usually a LinkNode, that replaces the match. The submatches are the match followed by the
+	// groups in Pattern, as returned by FindStringSubmatch. Return nil to keep the match as text.
+	Link(submatches []string, context *LinkContext) (InlineNode, error)
+}
+
+// LinkContext is what the autolinkers need to know about the changelog to create the URLs.
+type LinkContext struct {
+	// Repo is the short URL, e.g. `piot/clog`, of the repo that the text belongs to.
+	Repo string
+	// IssueReferences is true if `#123` in Repo refers to an issue instead of a pull request.
+	IssueReferences bool
+	Repos           map[string]RepoDefinition
+
+	rules []compiledAutolinkRule
+}
+
+func newLinkContext(root *ChangelogYaml) (*LinkContext, error) {
+	rules, err := compileAutolinkRules(root.Autolinks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkContext{
+		Repo:            root.Repo,
+		IssueReferences: root.References == IssueReferences,
+		Repos:           root.Repos,
+		rules:           rules,
+	}, nil
+}
+
+// forRepo returns a copy of the context for text that belongs to another repo.
+func (c *LinkContext) forRepo(definition RepoDefinition) *LinkContext {
+	repoContext := *c
+	repoContext.Repo = definition.Repo
+	repoContext.IssueReferences = definition.References == IssueReferences
+
+	return &repoContext
+}
+
+// ReferencesIssues returns true if `#123` means an issue in the repo with the short URL.
+func (c *LinkContext) ReferencesIssues(repoShortUrl string) bool {
+	if repoShortUrl == c.Repo {
+		return c.IssueReferences
+	}
+
+	for _, definition := range c.Repos {
+		if definition.Repo == repoShortUrl {
+			return definition.References == IssueReferences
+		}
+	}
+
+	return false
+}
+
+var (
+	autolinkersMutex sync.RWMutex
+	// autolinkers are run in this order, the most specific ones first. The autolink rules in the config are run
+	// before all of them, so a rule can take over e.g. `#123`. Only `GH-123` is never taken by a rule.
+	autolinkers = []Autolinker{
+		crossRepoCommitHashAutolinker{},
+		crossRepoReferenceAutolinker{},
+		repoKeyReferenceAutolinker{},
+		issueAutolinker{},
+		pullRequestAutolinker{},
+		commitHashAutolinker{},
+		profileAutolinker{},
+		emojiAutolinker{},
+	}
+)
+
+// RegisterAutolinker adds an autolinker that is run after the built-in ones, and after those registered before it.
+func RegisterAutolinker(autolinker Autolinker) {
+	autolinkersMutex.Lock()
+	defer autolinkersMutex.Unlock()
+
+	autolinkers = append(autolinkers, autolinker)
+}
+
+func registeredAutolinkers() []Autolinker {
+	autolinkersMutex.RLock()
+	defer autolinkersMutex.RUnlock()
+
+	return append([]Autolinker(nil), autolinkers...)
+}
+
+var (
+	// escapedReferenceRegexp matches `\#`, `\@` and `\$`, which are written to suppress the autolinking.
+	escapedReferenceRegexp = regexp.MustCompile(`\\[#@$]`)
+	// verbatimRegexp matches bare URLs and email addresses, that must not be changed by the autolinkers.
+	verbatimRegexp = regexp.MustCompile(`https?://[^\s<>]+|[\w.%+-]+@[\w-]+(?:\.[\w-]+)+`)
+)
+
+// tokenizeLine marks escaped references (without the backslash) as escaped text, and bare URLs and email addresses
+// as verbatim text.
+func tokenizeLine(inlines []InlineNode) []InlineNode {
+	tokenized, _ := replaceTextMatches(inlines, escapedReferenceRegexp, func(submatches []string) (InlineNode, error) {
+		return TextNode{Text: submatches[0][1:], Escaped: true}, nil
+	})
+
+	tokenized, _ = replaceTextMatches(tokenized, verbatimRegexp, func(submatches []string) (InlineNode, error) {
+		return TextNode{Text: submatches[0], Verbatim: true}, nil
+	})
+
+	return tokenized
+}
+
+func applyAutolinker(inlines []InlineNode, autolinker Autolinker, context *LinkContext) ([]InlineNode, error) {
+	return replaceTextMatches(inlines, autolinker.Pattern(), func(submatches []string) (InlineNode, error) {
+		return autolinker.Link(submatches, context)
+	})
+}
+
+// autolink runs the autolink rules in the context, followed by all registered autolinkers.
+func autolink(inlines []InlineNode, context *LinkContext) ([]InlineNode, error) {
+	inlines = tokenizeLine(inlines)
+
+	for index := range context.rules {
+		var err error
+		if inlines, err = applyAutolinker(inlines, &context.rules[index], context); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, autolinker := range registeredAutolinkers() {
+		var err error
+		if inlines, err = applyAutolinker(inlines, autolinker, context); err != nil {
+			return nil, err
+		}
+	}
+
+	return inlines, nil
+}
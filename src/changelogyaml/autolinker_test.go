@@ -0,0 +1,162 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"regexp"
+	"testing"
+)
+
+func testLinkContext() *LinkContext {
+	return &LinkContext{
+		Repo: "piot/nimble",
+		Repos: map[string]RepoDefinition{
+			"clog": {Repo: "piot/clog"},
+		},
+	}
+}
+
+// describeInlines writes text as it is, links as `[name](url)`, code spans in backticks and emoji as `<name>`, so the
+// autolinking can be checked without the escaping of a formatter.
+func describeInlines(inlines []InlineNode) string {
+	description := ""
+	for _, inline := range inlines {
+		switch node := inline.(type) {
+		case TextNode:
+			description += node.Text
+		case LinkNode:
+			description += "[" + node.Name + "](" + node.URL + ")"
+		case CodeNode:
+			description += "`" + node.Code + "`"
+		case EmojiNode:
+			description += "<" + node.Name + ">"
+		}
+	}
+
+	return description
+}
+
+// renderLine autolinks the line and describes the result.
+func renderLine(t *testing.T, line string, context *LinkContext) string {
+	t.Helper()
+
+	inlines, err := convertTextLine(line, context)
+	if err != nil {
+		t.Fatalf("%q: %v", line, err)
+	}
+
+	return describeInlines(inlines)
+}
+
+func TestAutolinkPipeline(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"pull request", "Fix #12", "Fix [#12](https://github.com/piot/nimble/pull/12)"},
+		{"pull request with leading zeros keeps the text", "Fix #007",
+			"Fix [#007](https://github.com/piot/nimble/pull/7)"},
+		{"issue", "Fix GH-12", "Fix [GH-12](https://github.com/piot/nimble/issues/12)"},
+		{"commit hash", "Fix $abcdef1", "Fix [abcdef1](https://github.com/piot/nimble/commit/abcdef1)"},
+		{"short hash is text", "Fix $abc12", "Fix $abc12"},
+		{"profile", "Thanks @piot", "Thanks [@piot](https://github.com/piot)"},
+		{"escaped pull request", `Fix \#12`, `Fix #12`},
+		{"escaped profile", `Ask \@piot`, `Ask @piot`},
+		{"escaped hash", `Set \$abcdef1`, `Set $abcdef1`},
+		{"bare URL is verbatim", "See https://github.com/piot/nimble/pull/3#issuecomment-1",
+			"See https://github.com/piot/nimble/pull/3#issuecomment-1"},
+		{"email is verbatim", "Mail peter@example.com", "Mail peter@example.com"},
+		{"code span is kept", "Call `f(#12)`", "Call `f(#12)`"},
+		{"cross repo before pull request", "Fix piot/clog#3",
+			"Fix [piot/clog#3](https://github.com/piot/clog/pull/3)"},
+		{"cross repo hash before profile", "Fix piot/clog@abcdef1",
+			"Fix [piot/clog@abcdef1](https://github.com/piot/clog/commit/abcdef1)"},
+		{"repo key before pull request", "Fix clog#3", "Fix [clog#3](https://github.com/piot/clog/pull/3)"},
+		{"unknown repo key is text", "Fix other#3", "Fix other#3"},
+		{"too large pull request is text", "Fix #99999999999999999999", "Fix #99999999999999999999"},
+		{"too large issue is text", "Fix GH-99999999999999999999", "Fix GH-99999999999999999999"},
+		{"too large cross repo is text", "Fix piot/clog#99999999999999999999", "Fix piot/clog#99999999999999999999"},
+		{"too large repo key is text", "Fix clog#99999999999999999999", "Fix clog#99999999999999999999"},
+		{"emoji", "Fix it :rocket: now", "Fix it <rocket> now"},
+		{"emoji with plus", "Works :+1:", "Works <+1>"},
+		{"unknown emoji is text", "Fix :not_an_emoji:", "Fix :not_an_emoji:"},
+		{"time is not emoji", "At 10:100:00", "At 10:100:00"},
+		{"emoji in code is kept", "Use `:rocket:`", "Use `:rocket:`"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := renderLine(t, test.line, testLinkContext()); got != test.want {
+				t.Errorf("%q: got %q, want %q", test.line, got, test.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeLine(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []InlineNode
+	}{
+		{"escape drops backslash", `a \#1 b`, []InlineNode{
+			TextNode{Text: "a "}, TextNode{Text: "#", Escaped: true}, TextNode{Text: "1 b"},
+		}},
+		{"url", "see https://example.com/a#b", []InlineNode{
+			TextNode{Text: "see "}, TextNode{Text: "https://example.com/a#b", Verbatim: true},
+		}},
+		{"plain", "nothing here", []InlineNode{TextNode{Text: "nothing here"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := tokenizeLine([]InlineNode{TextNode{Text: test.text}})
+			if len(got) != len(test.want) {
+				t.Fatalf("got %#v, want %#v", got, test.want)
+			}
+			for index := range got {
+				if got[index] != test.want[index] {
+					t.Errorf("node %d: got %#v, want %#v", index, got[index], test.want[index])
+				}
+			}
+		})
+	}
+}
+
+type groupsAutolinker struct {
+	got [][]string
+}
+
+func (a *groupsAutolinker) Pattern() *regexp.Regexp {
+	return regexp.MustCompile(`(X)-(\d+)`)
+}
+
+func (a *groupsAutolinker) Link(submatches []string, _ *LinkContext) (InlineNode, error) {
+	a.got = append(a.got, submatches)
+
+	return nil, nil
+}
+
+func TestAutolinkerGetsSubmatches(t *testing.T) {
+	autolinker := &groupsAutolinker{}
+	if _, err := applyAutolinker([]InlineNode{TextNode{Text: "a X-1 b X-22"}}, autolinker,
+		testLinkContext()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{"X-1", "X", "1"}, {"X-22", "X", "22"}}
+	if len(autolinker.got) != len(want) {
+		t.Fatalf("got %v, want %v", autolinker.got, want)
+	}
+	for index := range want {
+		for group := range want[index] {
+			if autolinker.got[index][group] != want[index][group] {
+				t.Errorf("got %v, want %v", autolinker.got, want)
+			}
+		}
+	}
+}
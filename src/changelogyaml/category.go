@@ -5,7 +5,11 @@
 
 package changelogyaml
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 type CategoryType uint8
 
@@ -28,29 +32,32 @@ const (
 	Unreleased
 )
 
+// CategoryInfo is how a category is shown in front of each entry. Name is also used as the text label.
 type CategoryInfo struct {
 	EmojiName string
 	Name      string
+	// BadgeColor is a Shields color name, e.g. `red`, or a hex color without `#`.
+	BadgeColor string
 }
 
 func infoFromCategoryName(name CategoryType) CategoryInfo {
 	lookup := map[CategoryType]CategoryInfo{
-		Added:        {"star2", "added"},
-		Changed:      {"hammer_and_wrench", "changed"},
-		Fixed:        {"lady_beetle", "fixed"},
-		Workaround:   {"see_no_evil", "workaround"},
-		Performance:  {"zap", "performance"},
-		Tests:        {"vertical_traffic_light", "test"},
-		Removed:      {"fire", "removed"},
-		Improved:     {"art", "improved"},
-		Breaking:     {"triangular_flag_on_post", "breaking"},
-		Deprecated:   {"spider_web", "deprecated"},
-		Refactored:   {"recycle", "refactor"},
-		Experimental: {"alembic", "experimental"},
-		Docs:         {"book", "docs"},
-		Noted:        {"beetle", "known issue"},
-		Style:        {"gem", "style"},
-		Unreleased:   {"soon", "unreleased"},
+		Added:        {"star2", "added", "brightgreen"},
+		Changed:      {"hammer_and_wrench", "changed", "blue"},
+		Fixed:        {"lady_beetle", "fixed", "green"},
+		Workaround:   {"see_no_evil", "workaround", "yellow"},
+		Performance:  {"zap", "performance", "orange"},
+		Tests:        {"vertical_traffic_light", "test", "lightgrey"},
+		Removed:      {"fire", "removed", "red"},
+		Improved:     {"art", "improved", "informational"},
+		Breaking:     {"triangular_flag_on_post", "breaking", "critical"},
+		Deprecated:   {"spider_web", "deprecated", "important"},
+		Refactored:   {"recycle", "refactor", "blueviolet"},
+		Experimental: {"alembic", "experimental", "yellowgreen"},
+		Docs:         {"book", "docs", "informational"},
+		Noted:        {"beetle", "known issue", "inactive"},
+		Style:        {"gem", "style", "lightgrey"},
+		Unreleased:   {"soon", "unreleased", "inactive"},
 	}
 
 	info, wasFound := lookup[name]
@@ -60,3 +67,141 @@ func infoFromCategoryName(name CategoryType) CategoryInfo {
 
 	return info
 }
+
+// categoryYamlKeys are the keys in Changes, as written in the YAML file.
+var categoryYamlKeys = map[CategoryType]string{
+	Added:        "added",
+	Changed:      "changed",
+	Fixed:        "fixed",
+	Workaround:   "workaround",
+	Performance:  "performance",
+	Tests:        "tests",
+	Removed:      "removed",
+	Improved:     "improved",
+	Breaking:     "breaking",
+	Deprecated:   "deprecated",
+	Refactored:   "refactored",
+	Experimental: "experimental",
+	Docs:         "docs",
+	Noted:        "noted",
+	Style:        "style",
+	Unreleased:   "unreleased",
+}
+
+// categoryFromKey returns the category for a key in Changes, e.g. `fixed`. Keys are case-sensitive, the same as
+// when the YAML file is decoded, so `Fixed` is not a category.
+func categoryFromKey(key string) (CategoryType, bool) {
+	for category, categoryKey := range categoryYamlKeys {
+		if categoryKey == key {
+			return category, true
+		}
+	}
+
+	return 0, false
+}
+
+// YamlKeyFromCategory returns the key that is used for the category in the YAML file, e.g. `fixed`.
+func YamlKeyFromCategory(category CategoryType) string {
+	key, found := categoryYamlKeys[category]
+	if !found {
+		panic(fmt.Errorf("unknown '%v'", category))
+	}
+
+	return key
+}
+
+// CategoryInfos returns the info of every category, with the overrides from ChangelogYaml.Categories applied.
+func (c *ChangelogYaml) CategoryInfos() (map[CategoryType]CategoryInfo, error) {
+	infos := make(map[CategoryType]CategoryInfo)
+	for category := range categoryYamlKeys {
+		infos[category] = infoFromCategoryName(category)
+	}
+
+	for key, override := range c.Categories {
+		category, found := categoryFromKey(key)
+		if !found {
+			return nil, fmt.Errorf("unknown category '%s' in categories", key)
+		}
+
+		info := infos[category]
+		if override.Emoji != "" {
+			info.EmojiName = override.Emoji
+		}
+		if override.Name != "" {
+			info.Name = override.Name
+		}
+		if override.BadgeColor != "" {
+			info.BadgeColor = override.BadgeColor
+		}
+		infos[category] = info
+	}
+
+	return infos, nil
+}
+
+// lookupCategoryInfo returns the info from categories, or the default info if it is not there.
+func lookupCategoryInfo(categories map[CategoryType]CategoryInfo, category CategoryType) CategoryInfo {
+	if info, found := categories[category]; found {
+		return info
+	}
+
+	return infoFromCategoryName(category)
+}
+
+// CategoryIconStyle selects what is written in front of each entry to show its category.
+type CategoryIconStyle uint8
+
+const (
+	// EmojiIcons writes the emoji of the category. Breaking entries also get a `[breaking]` label.
+	EmojiIcons CategoryIconStyle = iota
+	// LabelIcons writes the name as a text label, e.g. `[fixed]`.
+	LabelIcons
+	// BadgeIcons writes a Shields badge image.
+	BadgeIcons
+	// NoIcons writes nothing.
+	NoIcons
+)
+
+// ParseCategoryIconStyle returns the icon style for a name: emoji, label, badge or none.
+func ParseCategoryIconStyle(name string) (CategoryIconStyle, error) {
+	switch name {
+	case "emoji":
+		return EmojiIcons, nil
+	case "label":
+		return LabelIcons, nil
+	case "badge":
+		return BadgeIcons, nil
+	case "none":
+		return NoIcons, nil
+	}
+
+	return 0, fmt.Errorf("unknown category icon style '%s'", name)
+}
+
+// shieldsEscaper escapes the characters that have a special meaning in a Shields static badge.
+var shieldsEscaper = strings.NewReplacer("-", "--", "_", "__", " ", "_")
+
+// badgeURL returns the URL of a Shields static badge with the text.
+func badgeURL(text string, color string) string {
+	return "https://img.shields.io/badge/" + url.PathEscape(shieldsEscaper.Replace(text)) + "-" +
+		url.PathEscape(color)
+}
+
+// categoryIcon returns the icon for the category in the style, or an empty string if there is none.
+func categoryIcon(category CategoryType, info CategoryInfo, style CategoryIconStyle, formatter Formatter) string {
+	switch style {
+	case LabelIcons:
+		return formatter.Text(fmt.Sprintf("[%v]", info.Name))
+	case BadgeIcons:
+		return formatter.Image(info.Name, badgeURL(info.Name, info.BadgeColor))
+	case NoIcons:
+		return ""
+	}
+
+	icon := formatter.Emoji(info.EmojiName)
+	if category == Breaking {
+		icon += formatter.Text(fmt.Sprintf("[%v]", info.Name))
+	}
+
+	return icon
+}
@@ -0,0 +1,135 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "testing"
+
+func TestParseCategoryIconStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    CategoryIconStyle
+		isError bool
+	}{
+		{name: "emoji", want: EmojiIcons},
+		{name: "label", want: LabelIcons},
+		{name: "badge", want: BadgeIcons},
+		{name: "none", want: NoIcons},
+		{name: "Badge", isError: true},
+		{name: "", isError: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseCategoryIconStyle(test.name)
+		if (err != nil) != test.isError || got != test.want {
+			t.Errorf("ParseCategoryIconStyle(%q) = %v, %v", test.name, got, err)
+		}
+	}
+}
+
+func TestBadgeURL(t *testing.T) {
+	tests := []struct {
+		text  string
+		color string
+		want  string
+	}{
+		{"fixed", "blue", "https://img.shields.io/badge/fixed-blue"},
+		{"Bug fix", "ff8800", "https://img.shields.io/badge/Bug_fix-ff8800"},
+		{"re-factored", "red", "https://img.shields.io/badge/re--factored-red"},
+		{"snake_case", "red", "https://img.shields.io/badge/snake__case-red"},
+		{"a/b", "red", "https://img.shields.io/badge/a%2Fb-red"},
+	}
+
+	for _, test := range tests {
+		if got := badgeURL(test.text, test.color); got != test.want {
+			t.Errorf("badgeURL(%q, %q) = %q, want %q", test.text, test.color, got, test.want)
+		}
+	}
+}
+
+func TestCategoryIcons(t *testing.T) {
+	const yamlText = `repos:
+  main:
+    repo: piot/main
+categories:
+  fixed:
+    emoji: wrench
+    name: Bug fix
+    badgeColor: ff8800
+releases:
+  - name: 0.1.0
+    date: 2023-01-02
+    repos:
+      main:
+        fixed:
+          - Keep the window size
+        breaking:
+          - Rename the window
+        added:
+          - Add a window
+`
+
+	tests := []struct {
+		name      string
+		style     CategoryIconStyle
+		formatter Formatter
+		want      []string
+	}{
+		{"markdown emoji", EmojiIcons, &MarkdownFormatter{}, []string{
+			"* :triangular_flag_on_post:\\[breaking\\] Rename the window",
+			"* :star2: Add a window",
+			"* :wrench: Keep the window size",
+		}},
+		{"asciidoc emoji", EmojiIcons, &AsciiDocFormatter{}, []string{
+			"* &#x1F6A9;&#91;breaking&#93; Rename the window",
+			"* &#x1F31F; Add a window",
+			"* &#x1F527; Keep the window size",
+		}},
+		{"markdown label", LabelIcons, &MarkdownFormatter{}, []string{
+			"* \\[breaking\\] Rename the window",
+			"* \\[added\\] Add a window",
+			"* \\[Bug fix\\] Keep the window size",
+		}},
+		{"asciidoc label", LabelIcons, &AsciiDocFormatter{}, []string{
+			"* &#91;breaking&#93; Rename the window",
+			"* &#91;added&#93; Add a window",
+			"* &#91;Bug fix&#93; Keep the window size",
+		}},
+		{"markdown badge", BadgeIcons, &MarkdownFormatter{}, []string{
+			"* ![breaking](https://img.shields.io/badge/breaking-critical) Rename the window",
+			"* ![added](https://img.shields.io/badge/added-brightgreen) Add a window",
+			"* ![Bug fix](https://img.shields.io/badge/Bug_fix-ff8800) Keep the window size",
+		}},
+		{"asciidoc badge", BadgeIcons, &AsciiDocFormatter{}, []string{
+			"* image:https://img.shields.io/badge/breaking-critical[breaking] Rename the window",
+			"* image:https://img.shields.io/badge/added-brightgreen[added] Add a window",
+			"* image:https://img.shields.io/badge/Bug_fix-ff8800[Bug fix] Keep the window size",
+		}},
+		{"markdown none", NoIcons, &MarkdownFormatter{}, []string{
+			"* Rename the window",
+			"* Add a window",
+			"* Keep the window size",
+		}},
+		{"asciidoc none", NoIcons, &AsciiDocFormatter{}, []string{
+			"* Rename the window",
+			"* Add a window",
+			"* Keep the window size",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := renderTestDocument(t, yamlText, test.formatter, RenderOptions{CategoryIcons: test.style})
+			checkOrder(t, output, test.want)
+		})
+	}
+}
+
+func TestCategoryInfosUnknownOverride(t *testing.T) {
+	changelog := ChangelogYaml{Categories: map[string]CategoryOverride{"Fixed": {Emoji: "wrench"}}}
+	if _, err := changelog.CategoryInfos(); err == nil {
+		t.Error("expected an error for the unknown category 'Fixed'")
+	}
+}
@@ -0,0 +1,70 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "strings"
+
+// codeSpanAt returns the content of the code span that starts at position, and the position after it. A code span
+// starts with a run of backticks and ends with a run of the same length. The end is -1 if the backticks are unmatched.
+func codeSpanAt(text string, position int) (string, int) {
+	runLength := 1
+	for position+runLength < len(text) && text[position+runLength] == '`' {
+		runLength++
+	}
+
+	contentStart := position + runLength
+	closingOffset := findClosingFence(text[contentStart:], text[position:contentStart])
+	if closingOffset < 0 {
+		return "", -1
+	}
+
+	return text[contentStart : contentStart+closingOffset], contentStart + closingOffset + runLength
+}
+
+func findClosingFence(text string, fence string) int {
+	offset := 0
+	for {
+		index := strings.Index(text[offset:], fence)
+		if index < 0 {
+			return -1
+		}
+
+		end := offset + index + len(fence)
+		if end == len(text) || text[end] != '`' {
+			return offset + index
+		}
+
+		for end < len(text) && text[end] == '`' {
+			end++
+		}
+
+		offset = end
+	}
+}
+
+// wrapCodeSpan surrounds the code with enough backticks to not be closed by any backticks in the code.
+func wrapCodeSpan(code string) string {
+	longestRun := 0
+	run := 0
+
+	for _, ch := range code {
+		if ch == '`' {
+			run++
+			if run > longestRun {
+				longestRun = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	fence := strings.Repeat("`", longestRun+1)
+	if longestRun > 0 {
+		return fence + " " + code + " " + fence
+	}
+
+	return fence + code + fence
+}
@@ -10,26 +10,22 @@ import (
 	"regexp"
 )
 
-func replaceCommitHashLink(line string, repoShortUrl string, formatter Formatter) string {
-	re := regexp.MustCompile(`\$[a-f\d]*`)
-	allMatches := re.FindAllStringIndex(line, -1)
-
-	lineToPrint := ""
-	previousMatchPosition := 0
-
-	if len(allMatches) > 0 {
-		for _, match := range allMatches {
-			commitHashString := line[match[0]+1 : match[1]]
-			commitHashLink := fmt.Sprintf("%s%v/commit/%v", githubUrlPrefix, repoShortUrl, commitHashString)
-			commitHashLinkComplete := formatter.Link(commitHashString, commitHashLink)
-			lineToPrint += line[previousMatchPosition:match[0]] + commitHashLinkComplete
-			previousMatchPosition = match[1]
-		}
-
-		lineToPrint += line[previousMatchPosition:]
-	} else {
-		lineToPrint = line
-	}
-
-	return lineToPrint
+// minimumCommitHashLength is the length that git uses for short hashes. Shorter ones are not linked, so `$abc` in a
+// shell snippet is kept as text.
+const minimumCommitHashLength = 7
+
+var commitHashRegexp = regexp.MustCompile(fmt.Sprintf(`\B\$[a-f\d]{%d,40}\b`, minimumCommitHashLength))
+
+// commitHashAutolinker links `$hash` to the commit in the current repo.
+type commitHashAutolinker struct{}
+
+func (commitHashAutolinker) Pattern() *regexp.Regexp {
+	return commitHashRegexp
+}
+
+func (commitHashAutolinker) Link(submatches []string, context *LinkContext) (InlineNode, error) {
+	commitHashString := submatches[0][1:]
+	commitHashLink := fmt.Sprintf("%s%v/commit/%v", githubUrlPrefix, context.Repo, commitHashString)
+
+	return LinkNode{Kind: CommitHashLink, Name: commitHashString, URL: commitHashLink}, nil
 }
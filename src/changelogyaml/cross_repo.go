@@ -0,0 +1,80 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The owner and the repo key must start with a letter or digit. The owner also matches `_`, so `my_org-x/repo#1` is
+// one match, that is kept as text, instead of a link to `x/repo`.
+var (
+	crossRepoReferenceRegexp  = regexp.MustCompile(`\b([A-Za-z\d][\w-]*/[A-Za-z\d._-]+)#(\d+)\b`)
+	crossRepoCommitHashRegexp = regexp.MustCompile(`\b([A-Za-z\d][\w-]*/[A-Za-z\d._-]+)@([a-f\d]{7,40})\b`)
+	repoKeyReferenceRegexp    = regexp.MustCompile(`\b([A-Za-z\d][\w.-]*)#(\d+)\b`)
+	issueReferenceRegexp      = regexp.MustCompile(`\bGH-(\d+)\b`)
+	// githubOwnerRegexp matches the owners that GitHub allows: letters, digits and hyphens.
+	githubOwnerRegexp = regexp.MustCompile(`^[A-Za-z\d][A-Za-z\d-]*/`)
+)
+
+// crossRepoCommitHashAutolinker links `owner/repo@sha`.
+type crossRepoCommitHashAutolinker struct{}
+
+func (crossRepoCommitHashAutolinker) Pattern() *regexp.Regexp {
+	return crossRepoCommitHashRegexp
+}
+
+func (crossRepoCommitHashAutolinker) Link(submatches []string, _ *LinkContext) (InlineNode, error) {
+	if !githubOwnerRegexp.MatchString(submatches[1]) {
+		return nil, nil
+	}
+
+	return LinkNode{Kind: CommitHashLink, Name: submatches[0],
+		URL: fmt.Sprintf("%s%v/commit/%v", githubUrlPrefix, submatches[1], submatches[2])}, nil
+}
+
+// crossRepoReferenceAutolinker links `owner/repo#123`.
+type crossRepoReferenceAutolinker struct{}
+
+func (crossRepoReferenceAutolinker) Pattern() *regexp.Regexp {
+	return crossRepoReferenceRegexp
+}
+
+func (crossRepoReferenceAutolinker) Link(submatches []string, context *LinkContext) (InlineNode, error) {
+	if !githubOwnerRegexp.MatchString(submatches[1]) {
+		return nil, nil
+	}
+
+	return referenceLink(submatches[0], submatches[1], context.ReferencesIssues(submatches[1]), submatches[2])
+}
+
+// repoKeyReferenceAutolinker links `repoKey#123`, where repoKey is defined in ChangelogYaml.Repos.
+type repoKeyReferenceAutolinker struct{}
+
+func (repoKeyReferenceAutolinker) Pattern() *regexp.Regexp {
+	return repoKeyReferenceRegexp
+}
+
+func (repoKeyReferenceAutolinker) Link(submatches []string, context *LinkContext) (InlineNode, error) {
+	definition, found := context.Repos[submatches[1]]
+	if !found {
+		return nil, nil
+	}
+
+	return referenceLink(submatches[0], definition.Repo, definition.References == IssueReferences, submatches[2])
+}
+
+// issueAutolinker links `GH-123` to the issue in the current repo.
+type issueAutolinker struct{}
+
+func (issueAutolinker) Pattern() *regexp.Regexp {
+	return issueReferenceRegexp
+}
+
+func (issueAutolinker) Link(submatches []string, context *LinkContext) (InlineNode, error) {
+	return referenceLink(submatches[0], context.Repo, true, submatches[1])
+}
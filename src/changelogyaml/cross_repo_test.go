@@ -0,0 +1,76 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCrossRepoReferences(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"repo key range", "Fixes clog#1-#3",
+			"Fixes [clog#1](https://github.com/piot/clog/pull/1)-[#3](https://github.com/piot/nimble/pull/3)"},
+		{"unknown key range", "Fixes core#1-#3", "Fixes core#1-[#3](https://github.com/piot/nimble/pull/3)"},
+		{"owner after underscore", "_x-/y#1", "_x-/y#1"},
+		{"owner with underscore", "my_org-x/repo#12", "my_org-x/repo#12"},
+		{"hash with underscore owner", "my_org-x/repo@abcdef1", "my_org-x/repo@abcdef1"},
+		{"owner with hyphen", "my-org/repo#12", "[my-org/repo#12](https://github.com/my-org/repo/pull/12)"},
+		{"dot repo", "piot/.github#2", "[piot/.github#2](https://github.com/piot/.github/pull/2)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := renderLine(t, test.line, testLinkContext()); got != test.want {
+				t.Errorf("%q: got %q, want %q", test.line, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCrossRepoReferencesInDocument(t *testing.T) {
+	changelogs := []string{`
+repo: piot/nimble
+releases:
+  - name: v1.0.0
+    date: 2023-06-22
+    sections:
+      main:
+        changes:
+          fixed:
+            - "Fixes core#1-#3"
+            - "Fix _x-/y#1"
+`, `
+repo: piot/nimble
+repos:
+  core:
+    repo: piot/core
+releases:
+  - name: v1.0.0
+    date: 2023-06-22
+    repos:
+      core:
+        fixed:
+          - "Fixes core#1-#3"
+          - "Fix _x-/y#1"
+`}
+
+	for _, changelog := range changelogs {
+		root, err := ReadChangelog(bytes.NewBufferString(changelog), ".")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var output bytes.Buffer
+		if err := WriteDocument(root, &MarkdownFormatter{}, &output); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the ISO-8601 forms that are accepted in Release.Date.
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+}
+
+// ParseReleaseDate parses an ISO-8601 date, e.g. `2023-06-22`, or a date and time with zone, e.g.
+// `2023-06-22T14:30:00+02:00`.
+func ParseReleaseDate(date string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, date); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("bad date '%s', expected YYYY-MM-DD or YYYY-MM-DDThh:mm:ss with zone", date)
+}
+
+// dateOnly returns the day of t, without the time and zone, so that dates can be compared by day.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type dateLocale struct {
+	// longLayout is a Go time layout, where January and Monday are replaced with the names below.
+	longLayout    string
+	months        [12]string
+	shortMonths   [12]string
+	weekdays      [7]string
+	shortWeekdays [7]string
+	relative      relativeWords
+}
+
+// relativeUnit is how a count of a unit is written, with `%d` for the count.
+type relativeUnit struct {
+	one   string
+	other string
+}
+
+// relativeWords are the words of the "relative" date format. ago has `%s` for the count and unit, e.g. `%s ago`.
+type relativeWords struct {
+	future    string
+	today     string
+	yesterday string
+	ago       string
+	day       relativeUnit
+	week      relativeUnit
+	month     relativeUnit
+	year      relativeUnit
+}
+
+var englishLocale = dateLocale{
+	longLayout: "2 January 2006",
+	months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September",
+		"October", "November", "December"},
+	shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	relative: relativeWords{
+		future: "in the future", today: "today", yesterday: "yesterday", ago: "%s ago",
+		day: relativeUnit{"1 day", "%d days"}, week: relativeUnit{"1 week", "%d weeks"},
+		month: relativeUnit{"1 month", "%d months"}, year: relativeUnit{"1 year", "%d years"},
+	},
+}
+
+var cjkMonths = [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月",
+	"12月"}
+
+var dateLocales = map[string]dateLocale{
+	"en": englishLocale,
+	"de": {
+		longLayout: "2. January 2006",
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September",
+			"Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.",
+			"Dez."},
+		weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		relative: relativeWords{
+			future: "in der Zukunft", today: "heute", yesterday: "gestern", ago: "vor %s",
+			day: relativeUnit{"1 Tag", "%d Tagen"}, week: relativeUnit{"1 Woche", "%d Wochen"},
+			month: relativeUnit{"1 Monat", "%d Monaten"}, year: relativeUnit{"1 Jahr", "%d Jahren"},
+		},
+	},
+	"fr": {
+		longLayout: "2 January 2006",
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre",
+			"octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.",
+			"nov.", "déc."},
+		weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		relative: relativeWords{
+			future: "dans le futur", today: "aujourd'hui", yesterday: "hier", ago: "il y a %s",
+			day: relativeUnit{"1 jour", "%d jours"}, week: relativeUnit{"1 semaine", "%d semaines"},
+			month: relativeUnit{"1 mois", "%d mois"}, year: relativeUnit{"1 an", "%d ans"},
+		},
+	},
+	"es": {
+		longLayout: "2 de January de 2006",
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre",
+			"octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov",
+			"dic"},
+		weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		relative: relativeWords{
+			future: "en el futuro", today: "hoy", yesterday: "ayer", ago: "hace %s",
+			day: relativeUnit{"1 día", "%d días"}, week: relativeUnit{"1 semana", "%d semanas"},
+			month: relativeUnit{"1 mes", "%d meses"}, year: relativeUnit{"1 año", "%d años"},
+		},
+	},
+	"sv": {
+		longLayout: "2 January 2006",
+		months: [12]string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september",
+			"oktober", "november", "december"},
+		shortMonths: [12]string{"jan.", "feb.", "mars", "apr.", "maj", "juni", "juli", "aug.", "sep.", "okt.",
+			"nov.", "dec."},
+		weekdays:      [7]string{"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+		shortWeekdays: [7]string{"sön", "mån", "tis", "ons", "tors", "fre", "lör"},
+		relative: relativeWords{
+			future: "i framtiden", today: "i dag", yesterday: "i går", ago: "för %s sedan",
+			day: relativeUnit{"1 dag", "%d dagar"}, week: relativeUnit{"1 vecka", "%d veckor"},
+			month: relativeUnit{"1 månad", "%d månader"}, year: relativeUnit{"1 år", "%d år"},
+		},
+	},
+	"ja": {
+		longLayout:  "2006年1月2日",
+		months:      cjkMonths,
+		shortMonths: cjkMonths,
+		weekdays: [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日",
+			"金曜日", "土曜日"},
+		shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		relative: relativeWords{
+			future: "未来", today: "今日", yesterday: "昨日", ago: "%s前",
+			day: relativeUnit{"1日", "%d日"}, week: relativeUnit{"1週間", "%d週間"},
+			month: relativeUnit{"1か月", "%dか月"}, year: relativeUnit{"1年", "%d年"},
+		},
+	},
+	"zh": {
+		longLayout:  "2006年1月2日",
+		months:      cjkMonths,
+		shortMonths: cjkMonths,
+		weekdays: [7]string{"星期日", "星期一", "星期二", "星期三", "星期四",
+			"星期五", "星期六"},
+		shortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		relative: relativeWords{
+			future: "将来", today: "今天", yesterday: "昨天", ago: "%s前",
+			day: relativeUnit{"1天", "%d天"}, week: relativeUnit{"1周", "%d周"},
+			month: relativeUnit{"1个月", "%d个月"}, year: relativeUnit{"1年", "%d年"},
+		},
+	},
+}
+
+// DateLocales returns the supported locales for RenderOptions.DateLocale.
+func DateLocales() []string {
+	return []string{"en", "de", "fr", "es", "sv", "ja", "zh"}
+}
+
+func lookupDateLocale(name string) (*dateLocale, error) {
+	if name == "" {
+		return &englishLocale, nil
+	}
+
+	// Only the language is used, so `de-CH` and `de_CH` are the same as `de`
+	language := name
+	if separator := strings.IndexAny(name, "-_"); separator >= 0 {
+		language = name[:separator]
+	}
+
+	locale, found := dateLocales[strings.ToLower(language)]
+	if !found {
+		return nil, fmt.Errorf("unknown date locale '%s', supported are %s", name, strings.Join(DateLocales(), ", "))
+	}
+
+	return &locale, nil
+}
+
+// dateNameTokens are the tokens in a Go time layout that are names, and must be translated.
+var dateNameTokens = regexp.MustCompile(`January|Jan|Monday|Mon`)
+
+// formatLocalizedDate formats the time with the Go layout, with the month and weekday names from the locale.
+func formatLocalizedDate(t time.Time, layout string, locale *dateLocale) string {
+	result := ""
+	previousMatchPosition := 0
+
+	for _, match := range dateNameTokens.FindAllStringIndex(layout, -1) {
+		result += t.Format(layout[previousMatchPosition:match[0]])
+
+		switch layout[match[0]:match[1]] {
+		case "January":
+			result += locale.months[t.Month()-1]
+		case "Jan":
+			result += locale.shortMonths[t.Month()-1]
+		case "Monday":
+			result += locale.weekdays[t.Weekday()]
+		case "Mon":
+			result += locale.shortWeekdays[t.Weekday()]
+		}
+
+		previousMatchPosition = match[1]
+	}
+
+	return result + t.Format(layout[previousMatchPosition:])
+}
+
+func timeAgo(count int, unit relativeUnit, words *relativeWords) string {
+	amount := unit.one
+	if count != 1 {
+		amount = fmt.Sprintf(unit.other, count)
+	}
+
+	return fmt.Sprintf(words.ago, amount)
+}
+
+// relativeDate returns how long before now the date is, in the language of the locale, e.g. `3 days ago`. It only
+// counts whole days.
+func relativeDate(t time.Time, now time.Time, locale *dateLocale) string {
+	words := &locale.relative
+	days := int(dateOnly(now).Sub(dateOnly(t)).Hours() / 24)
+
+	switch {
+	case days < 0:
+		return words.future
+	case days == 0:
+		return words.today
+	case days == 1:
+		return words.yesterday
+	case days < 14:
+		return timeAgo(days, words.day, words)
+	case days < 60:
+		return timeAgo(days/7, words.week, words)
+	case days < 365:
+		return timeAgo(days/30, words.month, words)
+	}
+
+	return timeAgo(days/365, words.year, words)
+}
+
+// formatReleaseDate writes the date as selected in options.DateFormat. With no DateFormat the date is written as it
+// is in the YAML file. Unknown locales use English, RenderDocument reports them before this is called.
+func formatReleaseDate(release *ReleaseNode, options RenderOptions, now time.Time) string {
+	if options.DateFormat == "" || release.Time.IsZero() {
+		return release.Date
+	}
+
+	locale, err := lookupDateLocale(options.DateLocale)
+	if err != nil {
+		locale = &englishLocale
+	}
+
+	switch options.DateFormat {
+	case "iso":
+		return release.Time.Format("2006-01-02")
+	case "long":
+		return formatLocalizedDate(release.Time, locale.longLayout, locale)
+	case "relative":
+		return relativeDate(release.Time, now, locale)
+	}
+
+	return formatLocalizedDate(release.Time, options.DateFormat, locale)
+}
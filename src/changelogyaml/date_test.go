@@ -0,0 +1,183 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReleaseDate(t *testing.T) {
+	tests := []struct {
+		date string
+		want time.Time
+	}{
+		{"2023-06-22", time.Date(2023, time.June, 22, 0, 0, 0, 0, time.UTC)},
+		{"2023-06-22T14:30:00Z", time.Date(2023, time.June, 22, 14, 30, 0, 0, time.UTC)},
+		{"2023-06-22T14:30:00+02:00", time.Date(2023, time.June, 22, 12, 30, 0, 0, time.UTC)},
+		{"2023-06-22T14:30+02:00", time.Date(2023, time.June, 22, 12, 30, 0, 0, time.UTC)},
+		{"2023-06-22T14:30:00.5Z", time.Date(2023, time.June, 22, 14, 30, 0, 500000000, time.UTC)},
+	}
+
+	for _, test := range tests {
+		got, err := ParseReleaseDate(test.date)
+		if err != nil {
+			t.Errorf("%q: %v", test.date, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("%q: got %v, want %v", test.date, got, test.want)
+		}
+	}
+}
+
+func TestParseReleaseDateErrors(t *testing.T) {
+	for _, date := range []string{"", "2023-13-01", "2023-02-30", "22 June 2023", "2023-06-22T14:30:00", "2023/06/22"} {
+		if _, err := ParseReleaseDate(date); err == nil {
+			t.Errorf("%q: expected an error", date)
+		}
+	}
+}
+
+func TestFormatReleaseDate(t *testing.T) {
+	release := ReleaseNode{
+		Date: "2023-06-22T14:30:00+02:00",
+		Time: time.Date(2023, time.June, 22, 14, 30, 0, 0, time.FixedZone("", 2*60*60)),
+	}
+	now := time.Date(2023, time.June, 25, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		format string
+		locale string
+		want   string
+	}{
+		{"", "", "2023-06-22T14:30:00+02:00"},
+		{"iso", "", "2023-06-22"},
+		{"long", "", "22 June 2023"},
+		{"long", "en-GB", "22 June 2023"},
+		{"long", "de", "22. Juni 2023"},
+		{"long", "fr", "22 juin 2023"},
+		{"long", "es", "22 de junio de 2023"},
+		{"long", "sv_SE", "22 juni 2023"},
+		{"long", "ja", "2023年6月22日"},
+		{"long", "zh", "2023年6月22日"},
+		{"Mon, Jan 2 2006", "", "Thu, Jun 22 2023"},
+		{"Monday 2 January", "de", "Donnerstag 22 Juni"},
+		{"relative", "", "3 days ago"},
+		{"relative", "de", "vor 3 Tagen"},
+		{"relative", "ja", "3日前"},
+	}
+
+	for _, test := range tests {
+		options := RenderOptions{DateFormat: test.format, DateLocale: test.locale}
+		if got := formatReleaseDate(&release, options, now); got != test.want {
+			t.Errorf("%q %q: got %q, want %q", test.format, test.locale, got, test.want)
+		}
+	}
+}
+
+func TestRelativeDate(t *testing.T) {
+	now := time.Date(2023, time.June, 22, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		date   time.Time
+		locale string
+		want   string
+	}{
+		{time.Date(2023, time.June, 22, 23, 0, 0, 0, time.UTC), "en", "today"},
+		{time.Date(2023, time.June, 21, 0, 0, 0, 0, time.UTC), "en", "yesterday"},
+		{time.Date(2023, time.June, 19, 0, 0, 0, 0, time.UTC), "en", "3 days ago"},
+		{time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC), "en", "3 weeks ago"},
+		{time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), "en", "3 months ago"},
+		{time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC), "en", "1 year ago"},
+		{time.Date(2023, time.June, 23, 0, 0, 0, 0, time.UTC), "en", "in the future"},
+		{time.Date(2023, time.June, 22, 0, 0, 0, 0, time.UTC), "de", "heute"},
+		{time.Date(2023, time.June, 19, 0, 0, 0, 0, time.UTC), "de", "vor 3 Tagen"},
+		{time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC), "de", "vor 1 Jahr"},
+		{time.Date(2023, time.June, 21, 0, 0, 0, 0, time.UTC), "fr", "hier"},
+		{time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), "fr", "il y a 3 mois"},
+		{time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC), "es", "hace 3 semanas"},
+		{time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC), "sv", "för 3 veckor sedan"},
+		{time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), "ja", "3か月前"},
+		{time.Date(2023, time.June, 19, 0, 0, 0, 0, time.UTC), "zh", "3天前"},
+		{time.Date(2023, time.June, 23, 0, 0, 0, 0, time.UTC), "zh", "将来"},
+	}
+
+	for _, test := range tests {
+		locale, err := lookupDateLocale(test.locale)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := relativeDate(test.date, now, locale); got != test.want {
+			t.Errorf("%v %q: got %q, want %q", test.date, test.locale, got, test.want)
+		}
+	}
+}
+
+func TestLookupDateLocale(t *testing.T) {
+	for _, name := range []string{"", "en", "DE", "de-CH", "ja_JP"} {
+		if _, err := lookupDateLocale(name); err != nil {
+			t.Errorf("%q: %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"xx", "-", "_", "-de", "english"} {
+		if _, err := lookupDateLocale(name); err == nil || !strings.Contains(err.Error(), "unknown date locale") {
+			t.Errorf("%q: expected the unknown locale error, got %v", name, err)
+		}
+	}
+}
+
+func TestDiagnoseDates(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want []string
+	}{
+		{"sorted", `
+releases:
+  - name: Unreleased
+  - name: v1.1.0
+    date: "2023-06-22T14:30:00+02:00"
+  - name: v1.0.0
+    date: 2023-06-01
+`, nil},
+		{"same day date and datetime", `
+releases:
+  - name: v1.0.1
+    date: 2023-06-22
+  - name: v1.0.0
+    date: "2023-06-22T14:30:00Z"
+`, nil},
+		{"problems", `
+releases:
+  - name: v1.3.0
+    date: 2999-01-01
+  - name: v1.2.0
+    date: 2023-13-01
+  - name: v1.1.0
+    date: 2022-01-01
+  - name: v1.0.0
+    date: 2023-01-01
+  - name: v0.9.0
+`, []string{"in the future", "bad date", "newest first", "has no date"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diagnostics := Diagnose([]byte(test.yaml))
+			if len(diagnostics) != len(test.want) {
+				t.Fatalf("got %v, want %d diagnostics", diagnostics, len(test.want))
+			}
+			for index, want := range test.want {
+				if !strings.Contains(diagnostics[index].Message, want) {
+					t.Errorf("diagnostic %d: got %q, want %q", index, diagnostics[index].Message, want)
+				}
+			}
+		})
+	}
+}
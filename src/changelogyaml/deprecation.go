@@ -0,0 +1,136 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PendingDeprecation is an identifier that has been deprecated, but not yet removed.
+type PendingDeprecation struct {
+	Identifier string
+	// GroupKey is the repo key or section name where it was deprecated.
+	GroupKey    string
+	ReleaseName string
+	Line        int
+	// ReleasesSince is the number of releases that have been made after the deprecation, not counting `Unreleased`.
+	ReleasesSince int
+}
+
+type DeprecationWarning struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (w DeprecationWarning) String() string {
+	return fmt.Sprintf("%d:%d: %s", w.Line, w.Column, w.Message)
+}
+
+type DeprecationReport struct {
+	Warnings []DeprecationWarning
+	Pending  []PendingDeprecation
+}
+
+// normalizeIdentifier makes `foo()` and `foo` the same identifier.
+func normalizeIdentifier(identifier string) string {
+	return strings.TrimSuffix(strings.TrimSpace(identifier), "()")
+}
+
+// entryIdentifiers returns the explicit identifier, or if it is not set, all code spans in the text.
+func entryIdentifiers(explicit string, text string) []string {
+	if explicit != "" {
+		return []string{normalizeIdentifier(explicit)}
+	}
+
+	var identifiers []string
+	for _, inline := range parseInlineMarkup(text) {
+		if code, isCode := inline.(CodeNode); isCode {
+			identifiers = append(identifiers, normalizeIdentifier(code.Code))
+		}
+	}
+
+	return identifiers
+}
+
+// CheckDeprecations matches every Removed entry with an earlier Deprecated entry in the same repo or section.
+// Releases are expected to be sorted with the newest release first. It warns when something is removed without a prior
+// deprecation, or when it has been deprecated for more than maxReleases releases (zero disables that warning).
+func CheckDeprecations(changes []ChangeNode, maxReleases int) DeprecationReport {
+	type identifierKey struct {
+		groupKind  GroupKind
+		groupKey   string
+		identifier string
+	}
+
+	var report DeprecationReport
+	deprecated := make(map[identifierKey]ChangeNode)
+
+	// Oldest release first, and within a release removals before deprecations, so something that is deprecated and
+	// removed in the same release is not seen as deprecated in a previous release.
+	sortedChanges := append([]ChangeNode(nil), changes...)
+	sort.SliceStable(sortedChanges, func(i, j int) bool {
+		if sortedChanges[i].ReleaseIndex != sortedChanges[j].ReleaseIndex {
+			return sortedChanges[i].ReleaseIndex > sortedChanges[j].ReleaseIndex
+		}
+		return sortedChanges[i].Category == Removed && sortedChanges[j].Category != Removed
+	})
+
+	for _, change := range sortedChanges {
+		switch change.Category {
+		case Deprecated:
+			for _, identifier := range entryIdentifiers(change.Entry.Deprecates, change.Text) {
+				deprecated[identifierKey{change.GroupKind, change.GroupKey, identifier}] = change
+			}
+		case Removed:
+			identifiers := entryIdentifiers(change.Entry.Removes, change.Text)
+			if len(identifiers) == 0 {
+				report.Warnings = append(report.Warnings, DeprecationWarning{change.Line, change.Column,
+					"removed entry has no identifier, add one in backticks or with 'removes:'"})
+			}
+
+			for _, identifier := range identifiers {
+				key := identifierKey{change.GroupKind, change.GroupKey, identifier}
+				if _, wasDeprecated := deprecated[key]; !wasDeprecated {
+					report.Warnings = append(report.Warnings, DeprecationWarning{change.Line, change.Column,
+						fmt.Sprintf("'%s' is removed in %s without being deprecated in a previous release", identifier,
+							change.ReleaseName)})
+					continue
+				}
+				delete(deprecated, key)
+			}
+		}
+	}
+
+	for key, change := range deprecated {
+		pending := PendingDeprecation{
+			Identifier:    key.identifier,
+			GroupKey:      key.groupKey,
+			ReleaseName:   change.ReleaseName,
+			Line:          change.Line,
+			ReleasesSince: change.NewerReleases,
+		}
+		report.Pending = append(report.Pending, pending)
+
+		if maxReleases > 0 && pending.ReleasesSince > maxReleases {
+			report.Warnings = append(report.Warnings, DeprecationWarning{change.Line, change.Column,
+				fmt.Sprintf("'%s' has been deprecated for %d releases, since %s", key.identifier,
+					pending.ReleasesSince, change.ReleaseName)})
+		}
+	}
+
+	sort.Slice(report.Pending, func(i, j int) bool {
+		return report.Pending[i].Line < report.Pending[j].Line
+	})
+
+	sort.SliceStable(report.Warnings, func(i, j int) bool {
+		return report.Warnings[i].Line < report.Warnings[j].Line
+	})
+
+	return report
+}
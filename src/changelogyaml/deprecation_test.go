@@ -0,0 +1,71 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "testing"
+
+func checkDeprecations(t *testing.T, yaml string, maxReleases int) DeprecationReport {
+	t.Helper()
+
+	root, err := ParseYamlNode([]byte(yaml))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return CheckDeprecations(ChangeNodes(root), maxReleases)
+}
+
+func TestDeprecationReleasesSince(t *testing.T) {
+	report := checkDeprecations(t, `
+releases:
+  - name: Unreleased
+    sections:
+      main:
+        changes:
+          added:
+            - Add `+"`clog_new()`"+`
+  - name: v1.2.0
+    date: 2023-06-22
+  - name: v1.1.0
+    date: 2023-06-01
+    sections:
+      main:
+        changes:
+          deprecated:
+            - Deprecate `+"`clog_old()`"+`
+`, 0)
+
+	if len(report.Pending) != 1 {
+		t.Fatalf("got %v, want one pending deprecation", report.Pending)
+	}
+
+	if report.Pending[0].ReleasesSince != 1 {
+		t.Errorf("got %d releases since the deprecation, want 1", report.Pending[0].ReleasesSince)
+	}
+}
+
+func TestDeprecationSectionAndRepoAreApart(t *testing.T) {
+	report := checkDeprecations(t, `
+releases:
+  - name: v1.1.0
+    date: 2023-06-22
+    repos:
+      main:
+        removed:
+          - Remove `+"`clog_old()`"+`
+  - name: v1.0.0
+    date: 2023-06-01
+    sections:
+      main:
+        changes:
+          deprecated:
+            - Deprecate `+"`clog_old()`"+`
+`, 0)
+
+	if len(report.Warnings) != 1 || len(report.Pending) != 1 {
+		t.Errorf("the removal in repo 'main' should not match the deprecation in section 'main', got %+v", report)
+	}
+}
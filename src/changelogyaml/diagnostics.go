@@ -0,0 +1,173 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Severity uint8
+
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+)
+
+// Diagnostic is a problem at a position in the YAML file. Line and Column are one-based, Length is in runes.
+type Diagnostic struct {
+	Severity Severity
+	Line     int
+	Column   int
+	Length   int
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s", d.Line, d.Column, d.Message)
+}
+
+var yamlErrorLineRegexp = regexp.MustCompile(`line (\d+): (.*)`)
+
+func nodeDiagnostic(severity Severity, node *yaml.Node, message string) Diagnostic {
+	return Diagnostic{
+		Severity: severity,
+		Line:     node.Line,
+		Column:   node.Column,
+		Length:   len([]rune(node.Value)),
+		Message:  message,
+	}
+}
+
+// isChangesKey returns true for all keys in Changes. Security is in Changes, but is not a category yet.
+func isChangesKey(key string) bool {
+	_, isCategory := categoryFromKey(key)
+
+	return isCategory || key == "security"
+}
+
+func diagnoseChanges(changes *yaml.Node, diagnostics []Diagnostic) []Diagnostic {
+	if changes == nil || changes.Kind != yaml.MappingNode {
+		return diagnostics
+	}
+
+	for i := 0; i+1 < len(changes.Content); i += 2 {
+		key := changes.Content[i]
+		if !isChangesKey(key.Value) {
+			diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, key,
+				fmt.Sprintf("unknown category '%s'", key.Value)))
+		}
+	}
+
+	return diagnostics
+}
+
+// diagnoseDates checks that every release, except the unreleased one, has a valid date that is not in the future,
+// and that the releases are sorted with the newest first.
+func diagnoseDates(releases *yaml.Node, now time.Time, diagnostics []Diagnostic) []Diagnostic {
+	var newer time.Time
+	var newerName string
+
+	for _, release := range releases.Content {
+		name := ""
+		if nameNode := mappingValue(release, "name"); nameNode != nil {
+			name = nameNode.Value
+		}
+
+		date := mappingValue(release, "date")
+		if date == nil || date.Value == "" {
+			if !(&Release{Name: name}).IsUnreleased() {
+				diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, release,
+					fmt.Sprintf("release '%s' has no date, only 'Unreleased' can be without a date", name)))
+			}
+			continue
+		}
+
+		parsed, err := ParseReleaseDate(date.Value)
+		if err != nil {
+			diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, date, err.Error()))
+			continue
+		}
+
+		if dateOnly(parsed).After(dateOnly(now)) {
+			diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, date,
+				fmt.Sprintf("date %s is in the future", date.Value)))
+		}
+
+		// Compared by day, since a release with only a date can be written next to one with a time
+		if !newer.IsZero() && dateOnly(parsed).After(dateOnly(newer)) {
+			diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, date,
+				fmt.Sprintf("release '%s' (%s) is newer than '%s' above it, "+
+					"releases must be sorted with the newest first", name, date.Value, newerName)))
+		}
+
+		newer = parsed
+		newerName = name
+	}
+
+	return diagnostics
+}
+
+// Diagnose checks the YAML file for syntax errors, unknown categories, repos that are not defined and bad, missing,
+// future or unsorted dates.
+func Diagnose(data []byte) []Diagnostic {
+	root, err := ParseYamlNode(data)
+	if err != nil {
+		diagnostic := Diagnostic{Severity: SeverityError, Line: 1, Column: 1, Message: err.Error()}
+		if parts := yamlErrorLineRegexp.FindStringSubmatch(err.Error()); parts != nil {
+			diagnostic.Line, _ = strconv.Atoi(parts[1])
+			diagnostic.Message = parts[2]
+		}
+		return []Diagnostic{diagnostic}
+	}
+
+	var diagnostics []Diagnostic
+
+	definedRepos := mappingValue(root, "repos")
+
+	releases := mappingValue(root, "releases")
+	if releases == nil || releases.Kind != yaml.SequenceNode {
+		return diagnostics
+	}
+
+	diagnostics = diagnoseDates(releases, time.Now(), diagnostics)
+
+	for _, release := range releases.Content {
+
+		if sections := mappingValue(release, "sections"); sections != nil && sections.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(sections.Content); i += 2 {
+				diagnostics = diagnoseChanges(mappingValue(sections.Content[i+1], "changes"), diagnostics)
+			}
+		}
+
+		if repos := mappingValue(release, "repos"); repos != nil && repos.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(repos.Content); i += 2 {
+				key := repos.Content[i]
+				if mappingValue(definedRepos, key.Value) == nil {
+					diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, key,
+						fmt.Sprintf("repo '%s' is not defined in repos", key.Value)))
+				}
+				diagnostics = diagnoseChanges(repos.Content[i+1], diagnostics)
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// CategoryKeys returns the keys of all categories, in the order they are written in the document.
+func CategoryKeys() []string {
+	var keys []string
+	for _, lineInfo := range lineInfosFromChanges(&Changes{}) {
+		keys = append(keys, YamlKeyFromCategory(lineInfo.Category))
+	}
+
+	return keys
+}
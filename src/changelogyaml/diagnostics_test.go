@@ -0,0 +1,59 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDiagnoseAgreesWithRender(t *testing.T) {
+	tests := []struct {
+		key   string
+		known bool
+	}{
+		{key: "fixed", known: true},
+		{key: "breaking", known: true},
+		{key: "Fixed", known: false},
+		{key: "FIXED", known: false},
+		{key: "Breaking", known: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			yamlText := fmt.Sprintf(`repos:
+  main:
+    repo: piot/main
+releases:
+  - name: 0.1.0
+    date: 2023-01-02
+    repos:
+      main:
+        %s:
+          - Keep the window size
+`, test.key)
+
+			diagnostics := Diagnose([]byte(yamlText))
+			var unknown bool
+			for _, diagnostic := range diagnostics {
+				if strings.Contains(diagnostic.Message, "unknown category") {
+					unknown = true
+				}
+			}
+
+			output := renderTestDocument(t, yamlText, &MarkdownFormatter{}, RenderOptions{})
+			rendered := strings.Contains(output, "Keep the window size")
+
+			if unknown == test.known {
+				t.Errorf("unknown category diagnostic is %v, diagnostics: %v", unknown, diagnostics)
+			}
+			if rendered != test.known {
+				t.Errorf("rendered is %v in:\n%s", rendered, output)
+			}
+		})
+	}
+}
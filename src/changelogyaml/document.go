@@ -9,96 +9,172 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"time"
 )
 
-func WriteDocument(root *ChangelogYaml, outputFormatter Formatter, writer io.Writer) error {
-	if _, err := fmt.Fprint(writer, outputFormatter.Heading(1, "Changelog")); err != nil {
-		return err
+func sortedSectionNames(release *Release) []string {
+	sortedKeys := make([]string, 0, len(release.Sections))
+	for key := range release.Sections {
+		sortedKeys = append(sortedKeys, key)
 	}
 
-	for _, release := range root.Releases {
-		completeReleaseLinkURL := fmt.Sprintf("%s%v/releases/tag/%v", githubUrlPrefix, root.Repo, release.Name)
-		formattedReleaseLink := outputFormatter.Link(release.Name, completeReleaseLinkURL)
+	sort.Slice(sortedKeys, func(i, j int) bool {
+		return release.Sections[sortedKeys[i]].Order < release.Sections[sortedKeys[j]].Order
+	})
 
-		releaseLink := fmt.Sprintf("%v (%v)", formattedReleaseLink, release.Date)
-		releaseHeading := fmt.Sprintf("%s %v", outputFormatter.Emoji("bookmark"), releaseLink)
-		if _, err := fmt.Fprint(writer, outputFormatter.Heading(2, releaseHeading)); err != nil {
-			return err
-		}
+	return sortedKeys
+}
 
-		if release.Notice != "" {
-			notice := replaceAdmonition(release.Notice, outputFormatter)
-			notice = replaceAtProfileLink(notice, outputFormatter)
-			fmt.Fprintf(writer, "%v\n\n", notice)
-		}
+func sortedRepoNames(release *Release) []string {
+	sortedRepoNames := make([]string, 0, len(release.Repos))
+	for k := range release.Repos {
+		sortedRepoNames = append(sortedRepoNames, k)
+	}
+
+	sort.Strings(sortedRepoNames)
 
-		sortedKeys := make([]string, 0, len(release.Sections))
-		for key := range release.Sections {
-			sortedKeys = append(sortedKeys, key)
+	return sortedRepoNames
+}
+
+// releaseDate returns the parsed date of the release, or the zero time for an unreleased release without a date.
+func releaseDate(release *Release) (time.Time, error) {
+	if release.Date == "" {
+		if release.IsUnreleased() {
+			return time.Time{}, nil
 		}
+		return time.Time{}, fmt.Errorf("release '%s' has no date", release.Name)
+	}
 
-		sort.Slice(sortedKeys, func(i, j int) bool {
-			return release.Sections[sortedKeys[i]].Order < release.Sections[sortedKeys[j]].Order
-		})
+	date, err := ParseReleaseDate(release.Date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("release '%s': %w", release.Name, err)
+	}
 
-		for _, sortedKey := range sortedKeys {
-			sectionInfo := release.Sections[sortedKey]
-			completeLine := sortedKey
+	return date, nil
+}
 
-			if _, err := fmt.Fprint(writer, outputFormatter.Heading(3, completeLine)); err != nil {
-				return err
-			}
+// releaseURL returns the release page of the tag. Unreleased changes have no tag, so it is the comparison between
+// the previous release and HEAD instead.
+func releaseURL(root *ChangelogYaml, index int) string {
+	release := &root.Releases[index]
+	if !release.IsUnreleased() {
+		return fmt.Sprintf("%s%v/releases/tag/%v", githubUrlPrefix, root.Repo, release.TagName())
+	}
 
-			if sectionInfo.Notice != "" {
-				notice := replaceAdmonition(sectionInfo.Notice, outputFormatter)
-				notice = replaceAtProfileLink(notice, outputFormatter)
-				fmt.Fprintf(writer, "%v\n\n", notice)
-			}
+	if index+1 < len(root.Releases) {
+		return fmt.Sprintf("%s%v/compare/%v...HEAD", githubUrlPrefix, root.Repo, root.Releases[index+1].TagName())
+	}
 
-			if err := textLinesForTheRepo(root.Repo, &sectionInfo.Changes, outputFormatter, writer); err != nil {
-				return err
-			}
+	return githubUrlPrefix + root.Repo
+}
+
+// BuildDocument converts the parsed changelog to a document tree. All autolinks (pull requests, commit hashes and
+// profiles) and admonitions are resolved into nodes, so formatters never need to parse text.
+func BuildDocument(root *ChangelogYaml) (*DocumentNode, error) {
+	document := &DocumentNode{
+		Title: "Changelog",
+		Repo:  root.Repo,
+		URL:   githubUrlPrefix + root.Repo,
+	}
 
-			fmt.Fprintf(writer, "\n")
+	context, err := newLinkContext(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Categories, err = root.CategoryInfos(); err != nil {
+		return nil, err
+	}
+
+	var ids anchorIDs
+
+	for index, release := range root.Releases {
+		releaseTime, err := releaseDate(&release)
+		if err != nil {
+			return nil, err
 		}
 
-		sortedRepoNames := make([]string, 0, len(release.Repos))
-		for k := range release.Repos {
-			sortedRepoNames = append(sortedRepoNames, k)
+		releaseNode := ReleaseNode{
+			ID:           ids.unique(release.Name),
+			Name:         release.Name,
+			Codename:     release.Codename,
+			Date:         release.Date,
+			Time:         releaseTime,
+			URL:          releaseURL(root, index),
+			Prerelease:   release.Prerelease,
+			Yanked:       release.Yanked,
+			YankedReason: release.YankedReason,
+			Links:        release.Links,
+			Assets:       release.Assets,
+			Notice:       convertNotice(release.Notice),
 		}
 
-		sort.Strings(sortedRepoNames)
+		for _, sectionName := range sortedSectionNames(&release) {
+			sectionInfo := release.Sections[sectionName]
 
-		for _, repoName := range sortedRepoNames {
-			repoInfo := release.Repos[repoName]
+			entries, err := entriesForTheRepo(context, &sectionInfo.Changes)
+			if err != nil {
+				return nil, err
+			}
+
+			releaseNode.Groups = append(releaseNode.Groups, GroupNode{
+				Kind:    SectionGroup,
+				ID:      ids.unique(releaseNode.ID + "-" + sectionName),
+				Key:     sectionName,
+				Name:    sectionName,
+				Repo:    root.Repo,
+				URL:     document.URL,
+				Notice:  convertNotice(sectionInfo.Notice),
+				Entries: entries,
+			})
+		}
+
+		for _, repoName := range sortedRepoNames(&release) {
+			repoChanges := release.Repos[repoName]
 
 			info, found := root.Repos[repoName]
 			if !found {
-				panic(fmt.Errorf("must have info for repoInfo '%s'", repoName))
+				return nil, fmt.Errorf("must have info for repoInfo '%s'", repoName)
 			}
 
-			repoURL := fmt.Sprintf("%s%v", githubUrlPrefix, info.Repo)
-			description := ""
+			entries, err := entriesForTheRepo(context.forRepo(info), &repoChanges)
+			if err != nil {
+				return nil, err
+			}
 
-			if info.Description != "" {
-				description = fmt.Sprintf(" - %v", info.Description)
+			name := info.Name
+			if name == "" {
+				name = repoName
 			}
 
-			formattedRepoLink := outputFormatter.Link(repoName, repoURL)
+			releaseNode.Groups = append(releaseNode.Groups, GroupNode{
+				Kind:        RepoGroup,
+				ID:          ids.unique(releaseNode.ID + "-" + repoName),
+				Key:         repoName,
+				Name:        name,
+				Repo:        info.Repo,
+				URL:         fmt.Sprintf("%s%v", githubUrlPrefix, info.Repo),
+				Description: info.Description,
+				Entries:     entries,
+			})
+		}
 
-			completeLine := fmt.Sprintf("%s%v", formattedRepoLink, description)
+		document.Releases = append(document.Releases, releaseNode)
+	}
 
-			if _, err := fmt.Fprint(writer, outputFormatter.Heading(3, completeLine)); err != nil {
-				return err
-			}
+	return document, nil
+}
 
-			if err := textLinesForTheRepo(info.Repo, &repoInfo, outputFormatter, writer); err != nil {
-				return err
-			}
+func WriteDocument(root *ChangelogYaml, outputFormatter Formatter, writer io.Writer) error {
+	return WriteDocumentWithOptions(root, outputFormatter, RenderOptions{}, writer)
+}
 
-			fmt.Fprintf(writer, "\n")
-		}
+func WriteDocumentWithOptions(root *ChangelogYaml, outputFormatter Formatter, options RenderOptions,
+	writer io.Writer) error {
+	document, err := BuildDocument(root)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return RenderDocument(document, outputFormatter, options, writer)
 }
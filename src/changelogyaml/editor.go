@@ -0,0 +1,229 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The editor functions are used by the language server. All lines and columns are one-based.
+
+type Completion struct {
+	Label         string
+	Detail        string
+	Documentation string
+}
+
+// Location is a position in the YAML file.
+type Location struct {
+	Line   int
+	Column int
+}
+
+// categoryDescriptions returns the descriptions of the categories from the JSON Schema, that is generated from the
+// doc comments in Changes.
+func categoryDescriptions() map[string]string {
+	var schema struct {
+		Defs struct {
+			Changes struct {
+				Properties map[string]struct {
+					Description string `json:"description"`
+				} `json:"properties"`
+			} `json:"Changes"`
+		} `json:"$defs"`
+	}
+
+	descriptions := make(map[string]string)
+	if err := json.Unmarshal(JSONSchema(), &schema); err != nil {
+		return descriptions
+	}
+
+	for key, property := range schema.Defs.Changes.Properties {
+		descriptions[key] = property.Description
+	}
+
+	return descriptions
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func lineKey(line string) string {
+	trimmed := strings.TrimLeft(line, " -")
+	colon := strings.Index(trimmed, ":")
+	if colon < 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(trimmed[:colon])
+}
+
+// parentKeys returns the keys of the mappings that contain the line, closest first, together with their indentation.
+func parentKeys(lines []string, lineIndex int, column int) ([]string, []int) {
+	var keys []string
+	var indents []int
+
+	// The editor can send a position from a version of the document that is longer than the one we have
+	if lineIndex < 0 || lineIndex > len(lines) {
+		return nil, nil
+	}
+
+	currentIndent := column - 1
+	if lineIndex < len(lines) && strings.TrimSpace(lines[lineIndex]) != "" {
+		currentIndent = indentation(lines[lineIndex])
+	}
+
+	for index := lineIndex - 1; index >= 0 && currentIndent > 0; index-- {
+		line := lines[index]
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		lineIndent := indentation(line)
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "- ") {
+			// A key after a sequence dash is indented as if the dash was a space
+			lineIndent += 2
+		}
+
+		if lineIndent < currentIndent {
+			keys = append(keys, lineKey(line))
+			indents = append(indents, indentation(line))
+			currentIndent = lineIndent
+		}
+	}
+
+	return keys, indents
+}
+
+// Completions returns the category keys when the position is within a repo or the changes of a section in a release,
+// and the repo keys from the repo definitions when the position is within the repos of a release.
+func Completions(data []byte, line int, column int) []Completion {
+	lines := strings.Split(string(data), "\n")
+	keys, indents := parentKeys(lines, line-1, column)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	isReleaseRepos := keys[0] == "repos" && indents[0] > 0
+	isChanges := keys[0] == "changes" || (len(keys) > 1 && keys[1] == "repos" && indents[1] > 0)
+
+	var completions []Completion
+
+	if isReleaseRepos {
+		definitions := make(map[string]RepoDefinition)
+		var c ChangelogYaml
+		if err := yaml.Unmarshal(data, &c); err == nil {
+			definitions = c.Repos
+		}
+
+		for key, definition := range definitions {
+			completions = append(completions, Completion{
+				Label:         key,
+				Detail:        definition.Repo,
+				Documentation: definition.Description,
+			})
+		}
+
+		sort.Slice(completions, func(i, j int) bool {
+			return completions[i].Label < completions[j].Label
+		})
+	} else if isChanges {
+		descriptions := categoryDescriptions()
+		for _, key := range CategoryKeys() {
+			category, _ := categoryFromKey(key)
+			completions = append(completions, Completion{
+				Label:         key,
+				Detail:        infoFromCategoryName(category).Name,
+				Documentation: descriptions[key],
+			})
+		}
+	}
+
+	return completions
+}
+
+// Hover returns the entry on the line rendered as Markdown, with all links resolved.
+func Hover(data []byte, line int) (string, bool) {
+	root, err := ParseYamlNode(data)
+	if err != nil {
+		return "", false
+	}
+
+	var c ChangelogYaml
+	if err := root.Decode(&c); err != nil {
+		return "", false
+	}
+
+	for _, change := range ChangeNodes(root) {
+		if change.Line != line {
+			continue
+		}
+
+		context, err := newLinkContext(&c)
+		if err != nil {
+			return err.Error(), true
+		}
+
+		if change.GroupKind == RepoGroup {
+			context = context.forRepo(c.Repos[change.GroupKey])
+		}
+
+		inlines, err := convertTextLine(change.Text, context)
+		if err != nil {
+			return err.Error(), true
+		}
+
+		formatter := &MarkdownFormatter{}
+		rendered := fmt.Sprintf("**%s** %s", infoFromCategoryName(change.Category).Name,
+			RenderInlines(inlines, formatter))
+
+		return rendered, true
+	}
+
+	return "", false
+}
+
+// Definition returns the location of the repo definition when the position is on a repo key in a release.
+func Definition(data []byte, line int, column int) (Location, bool) {
+	root, err := ParseYamlNode(data)
+	if err != nil {
+		return Location{}, false
+	}
+
+	definitions := mappingValue(root, "repos")
+	releases := mappingValue(root, "releases")
+	if definitions == nil || releases == nil {
+		return Location{}, false
+	}
+
+	for _, release := range releases.Content {
+		repos := mappingValue(release, "repos")
+		if repos == nil || repos.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(repos.Content); i += 2 {
+			key := repos.Content[i]
+			if key.Line != line || column < key.Column || column > key.Column+len([]rune(key.Value)) {
+				continue
+			}
+
+			for j := 0; j+1 < len(definitions.Content); j += 2 {
+				if definitions.Content[j].Value == key.Value {
+					return Location{Line: definitions.Content[j].Line, Column: definitions.Content[j].Column}, true
+				}
+			}
+		}
+	}
+
+	return Location{}, false
+}
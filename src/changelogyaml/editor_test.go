@@ -0,0 +1,33 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "testing"
+
+const editorTestChangelog = `repos:
+  clog:
+    repo: piot/clog
+releases:
+  - name: v1.0.0
+    sections:
+      main:
+        changes:
+          `
+
+func TestCompletions(t *testing.T) {
+	completions := Completions([]byte(editorTestChangelog), 9, 11)
+	if len(completions) != len(CategoryKeys()) {
+		t.Errorf("got %d completions, want the %d category keys", len(completions), len(CategoryKeys()))
+	}
+}
+
+func TestCompletionsOutsideDocument(t *testing.T) {
+	for _, line := range []int{0, -3, 10, 200} {
+		if completions := Completions([]byte(editorTestChangelog), line, 1); len(completions) != 0 {
+			t.Errorf("line %d: got %v, want no completions", line, completions)
+		}
+	}
+}
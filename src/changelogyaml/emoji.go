@@ -5,33 +5,110 @@
 
 package changelogyaml
 
-import "fmt"
-
-func emojiNameToUnicode(name string) int {
-	lookup := map[string]int{
-		"bookmark":                0x1F516,
-		"triangular_flag_on_post": 0x1f6a9,
-		"star2":                   0x1f31f,
-		"hammer_and_wrench":       0x1f6e0,
-		"lady_beetle":             0x1f41e,
-		"see_no_evil":             0x1f648,
-		"zap":                     0x26a1,
-		"vertical_traffic_light":  0x1f6a6,
-		"fire":                    0x1f525,
-		"art":                     0x1f3a8,
-		"spider_web":              0x1f578,
-		"recycle":                 0x267b,
-		"alembic":                 0x2697,
-		"book":                    0x1F4D6,
-		"noted":                   0x1FAB2,
-		"gem":                     0x1F48E,
-		"soon":                    0x1F51C,
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed emoji.txt
+var emojiTable string
+
+var (
+	emojiLookup     map[string]string
+	emojiLookupOnce sync.Once
+)
+
+func parseEmojiTable() map[string]string {
+	lookup := make(map[string]string)
+
+	for _, line := range strings.Split(emojiTable, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, codePoints, _ := strings.Cut(line, "\t")
+
+		var unicode strings.Builder
+		for _, codePoint := range strings.Fields(codePoints) {
+			value, err := strconv.ParseUint(codePoint, 16, 32)
+			if err != nil {
+				panic(fmt.Errorf("emoji table: %s: %w", name, err))
+			}
+			unicode.WriteRune(rune(value))
+		}
+
+		lookup[name] = unicode.String()
 	}
 
-	replacement, wasFound := lookup[name]
-	if !wasFound {
-		panic(fmt.Errorf("can not replace %s", name))
+	return lookup
+}
+
+// EmojiUnicode returns the Unicode emoji for a GitHub shortcode name, without the colons, e.g. `lady_beetle`.
+func EmojiUnicode(name string) (string, bool) {
+	emojiLookupOnce.Do(func() {
+		emojiLookup = parseEmojiTable()
+	})
+
+	unicode, found := emojiLookup[name]
+
+	return unicode, found
+}
+
+// EmojiStyle tells how a formatter writes emoji.
+type EmojiStyle uint8
+
+const (
+	// ShortcodeEmoji writes `:name:`, which is only rendered as emoji on some sites, like GitHub.
+	ShortcodeEmoji EmojiStyle = iota
+	// UnicodeEmoji writes the Unicode characters.
+	UnicodeEmoji
+	// NoEmoji leaves out all emoji.
+	NoEmoji
+)
+
+// ParseEmojiStyle returns the emoji style for a name: shortcode, unicode or none.
+func ParseEmojiStyle(name string) (EmojiStyle, error) {
+	switch name {
+	case "shortcode":
+		return ShortcodeEmoji, nil
+	case "unicode":
+		return UnicodeEmoji, nil
+	case "none":
+		return NoEmoji, nil
+	}
+
+	return 0, fmt.Errorf("unknown emoji style '%s'", name)
+}
+
+// withEmoji returns the emoji followed by a space and the text, or only the text if the formatter leaves out emoji.
+func withEmoji(formatter Formatter, name string, text string) string {
+	emoji := formatter.Emoji(name)
+	if emoji == "" {
+		return text
+	}
+
+	return emoji + " " + text
+}
+
+// emojiShortcodeRegexp matches `:shortcode:` that is not part of a word, so times like `10:30:00` are kept as text.
+var emojiShortcodeRegexp = regexp.MustCompile(`\B:([a-z\d_+-]+):\B`)
+
+// emojiAutolinker turns `:shortcode:` into an EmojiNode, so every formatter can write it in its own way. Unknown names
+// are kept as text.
+type emojiAutolinker struct{}
+
+func (emojiAutolinker) Pattern() *regexp.Regexp {
+	return emojiShortcodeRegexp
+}
+
+func (emojiAutolinker) Link(submatches []string, _ *LinkContext) (InlineNode, error) {
+	if _, found := EmojiUnicode(submatches[1]); !found {
+		return nil, nil
 	}
 
-	return replacement
+	return EmojiNode{Name: submatches[1]}, nil
 }
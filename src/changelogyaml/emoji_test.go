@@ -0,0 +1,100 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmojiUnicode(t *testing.T) {
+	tests := []struct {
+		name  string
+		want  string
+		found bool
+	}{
+		{name: "rocket", want: "\U0001F680", found: true},
+		{name: "lady_beetle", want: "\U0001F41E", found: true},
+		{name: "+1", want: "\U0001F44D", found: true},
+		{name: "bookmark", want: "\U0001F516", found: true},
+		{name: "not_an_emoji", found: false},
+		{name: "", found: false},
+	}
+
+	for _, test := range tests {
+		got, found := EmojiUnicode(test.name)
+		if got != test.want || found != test.found {
+			t.Errorf("EmojiUnicode(%q) = %q, %v, want %q, %v", test.name, got, found, test.want, test.found)
+		}
+	}
+}
+
+func TestParseEmojiStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    EmojiStyle
+		isError bool
+	}{
+		{name: "shortcode", want: ShortcodeEmoji},
+		{name: "unicode", want: UnicodeEmoji},
+		{name: "none", want: NoEmoji},
+		{name: "Unicode", isError: true},
+		{name: "", isError: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseEmojiStyle(test.name)
+		if (err != nil) != test.isError || got != test.want {
+			t.Errorf("ParseEmojiStyle(%q) = %v, %v", test.name, got, err)
+		}
+	}
+}
+
+func TestEmojiStyleDocument(t *testing.T) {
+	const yamlText = `repos:
+  main:
+    repo: piot/main
+releases:
+  - name: 0.1.0
+    date: 2023-01-02
+    repos:
+      main:
+        fixed:
+          - "Keep the window size :rocket:"
+`
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		want      []string
+		missing   []string
+	}{
+		{"markdown unicode", &MarkdownFormatter{EmojiStyle: UnicodeEmoji},
+			[]string{"## \U0001F516 [0.1.0]", "* \U0001F41E Keep the window size \U0001F680"},
+			[]string{":bookmark:", ":lady_beetle:", ":rocket:"}},
+		{"markdown none", &MarkdownFormatter{EmojiStyle: NoEmoji},
+			[]string{"## [0.1.0]", "* Keep the window size"},
+			[]string{":bookmark:", ":lady_beetle:", ":rocket:", "\U0001F516", "\U0001F41E", "\U0001F680"}},
+		{"asciidoc unicode", &AsciiDocFormatter{EmojiStyle: UnicodeEmoji},
+			[]string{"* &#x1F41E; Keep the window size &#x1F680;"},
+			[]string{":lady_beetle:", ":rocket:"}},
+		{"asciidoc none", &AsciiDocFormatter{EmojiStyle: NoEmoji},
+			[]string{"== link:", "* Keep the window size"},
+			[]string{"&#x", ":lady_beetle:", ":rocket:"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := renderTestDocument(t, yamlText, test.formatter, RenderOptions{})
+			checkOrder(t, output, test.want)
+			for _, part := range test.missing {
+				if strings.Contains(output, part) {
+					t.Errorf("%q should not be in:\n%s", part, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is a single change. It is usually written as a plain string, but can also be a mapping when more information
+// is needed:
+//
+//	removed:
+//	  - text: Remove `clog_old()`
+//	    removes: clog_old
+type Entry struct {
+	Text string
+
+	// Deprecates is the identifier that this entry deprecates. If not set, the identifiers within backticks are used.
+	Deprecates string `yaml:"deprecates,omitempty"`
+
+	// Removes is the identifier that this entry removes. If not set, the identifiers within backticks are used.
+	Removes string `yaml:"removes,omitempty"`
+
+	// Migration describes how to upgrade past a breaking, removed or changed entry.
+	Migration *Migration `yaml:"migration,omitempty"`
+}
+
+// Migration is written in the upgrade guide, usually with a code snippet before and after the change.
+//
+//	breaking:
+//	  - text: Rename `clog_info()` to `clog_information()`
+//	    migration:
+//	      description: Replace all calls.
+//	      language: c
+//	      before: clog_info("hello");
+//	      after: clog_information("hello");
+type Migration struct {
+	Description string `yaml:"description,omitempty"`
+	Language    string `yaml:"language,omitempty"`
+	Before      string `yaml:"before,omitempty"`
+	After       string `yaml:"after,omitempty"`
+}
+
+func (e *Entry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		e.Text = value.Value
+		return nil
+	}
+
+	type plainEntry Entry
+
+	return value.Decode((*plainEntry)(e))
+}
+
+func (e Entry) MarshalYAML() (interface{}, error) {
+	if e.Deprecates == "" && e.Removes == "" && e.Migration == nil {
+		return e.Text, nil
+	}
+
+	type plainEntry Entry
+
+	return plainEntry(e), nil
+}
@@ -13,10 +13,60 @@ const (
 	Warning
 )
 
+// Formatter converts the nodes of the document tree to the output format. Text and Code receive the unmodified
+// text, all other methods receive text that has already been formatted.
 type Formatter interface {
+	Text(text string) string
+	// Verbatim is text that must be written as it is, e.g. a bare URL that the reader autolinks.
+	Verbatim(text string) string
+	Code(code string) string
 	Heading(level int, header string) string
 	BulletPoint(text string) string
+	// ListItem is a bullet point that is nested depth levels, zero is the top level.
+	ListItem(depth int, text string) string
+	// Anchor is written on the line before a heading to give the heading a stable ID.
+	Anchor(id string) string
+	// InternalLink is a link to an anchor within the same document.
+	InternalLink(name string, id string) string
+	// BeginCollapsible starts a block that is collapsed by default, with only the summary visible.
+	BeginCollapsible(summary string) string
+	EndCollapsible() string
+	// Emoji is given the GitHub shortcode name, without colons. It returns an empty string if emoji are left out.
 	Emoji(name string) string
 	Link(name string, link string) string
+	// Image is an inline image, e.g. a badge.
+	Image(alt string, url string) string
+	CodeBlock(language string, code string) string
+	Strong(text string) string
+	Emphasis(text string) string
 	Admonition(admonitionType AdmonitionType, text string) string
 }
+
+// RenderOptions controls the optional parts of the document that RenderDocument writes.
+type RenderOptions struct {
+	// TableOfContents adds a list of all releases, sections and repos after the "Changelog" heading.
+	TableOfContents bool
+
+	// MaxReleases limits the output to the newest releases. Zero means that all releases are written.
+	MaxReleases int
+
+	// CollapseAfter puts all releases after the first CollapseAfter releases in a collapsible block. Zero disables it.
+	CollapseAfter int
+
+	// ArchiveURL adds a footer link to the complete changelog, useful together with MaxReleases.
+	ArchiveURL string
+
+	// Contributors adds a list of all users mentioned in each release.
+	Contributors bool
+
+	// CategoryIcons selects what is written in front of each entry to show its category.
+	CategoryIcons CategoryIconStyle
+
+	// DateFormat is how release dates are written: "iso", "long" (e.g. `22 June 2023`), "relative" (e.g.
+	// `3 days ago`) or a Go time layout, e.g. "Jan 2, 2006". Empty writes the date as it is in the YAML file.
+	DateFormat string
+
+	// DateLocale is the language of month and weekday names and of relative dates, e.g. "de" or "ja". Empty is
+	// English. See DateLocales.
+	DateLocale string
+}
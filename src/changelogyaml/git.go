@@ -0,0 +1,92 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// LocalGitRepository is a GitRepository that runs the git command line tool.
+type LocalGitRepository struct {
+	// GitDir is the `.git` directory, or the working tree that contains it.
+	GitDir string
+}
+
+// NewLocalGitRepository returns a LocalGitRepository for gitDir, or an error if it is not a git repository.
+func NewLocalGitRepository(gitDir string) (*LocalGitRepository, error) {
+	repository := &LocalGitRepository{GitDir: gitDir}
+	if _, _, err := repository.git("rev-parse", "--git-dir"); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// isUnknownRevision returns true if git failed because a revision, e.g. a tag, does not exist.
+func isUnknownRevision(stderr string) bool {
+	return strings.Contains(stderr, "unknown revision") || strings.Contains(stderr, "bad revision")
+}
+
+// git runs the git command. The bool is false if git reported that a revision does not exist, all other failures
+// are returned as errors.
+func (r *LocalGitRepository) git(args ...string) (string, bool, error) {
+	command := exec.Command("git", append([]string{"-C", r.GitDir}, args...)...)
+	output, err := command.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			stderr := strings.TrimSpace(string(exitError.Stderr))
+			if isUnknownRevision(stderr) {
+				return "", false, nil
+			}
+			if stderr != "" {
+				return "", false, fmt.Errorf("git %s: %s", strings.Join(args, " "), stderr)
+			}
+		}
+		return "", false, fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+
+	return strings.TrimSpace(string(output)), true, nil
+}
+
+func (r *LocalGitRepository) ResolveCommit(prefix string) ([]string, error) {
+	candidates, _, err := r.git("rev-parse", "--disambiguate="+prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []string
+	for _, candidate := range strings.Fields(candidates) {
+		objectType, _, err := r.git("cat-file", "-t", candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		if objectType == "commit" {
+			commits = append(commits, candidate)
+		}
+	}
+
+	return commits, nil
+}
+
+func (r *LocalGitRepository) TagDate(tag string) (time.Time, bool, error) {
+	date, found, err := r.git("show", "-s", "--format=%cI", "refs/tags/"+tag+"^{commit}", "--")
+	if err != nil || !found {
+		return time.Time{}, false, err
+	}
+
+	commitDate, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("tag '%s': %w", tag, err)
+	}
+
+	return commitDate, true, nil
+}
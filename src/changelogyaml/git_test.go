@@ -0,0 +1,139 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// newTestGitRepository creates a repository with one commit, dated 2023-01-02, that is tagged v1.0.0.
+func newTestGitRepository(t *testing.T) (*LocalGitRepository, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		command := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		command.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE=2023-01-02T12:00:00Z", "GIT_COMMITTER_DATE=2023-01-02T12:00:00Z")
+		output, err := command.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	run("init", "-q")
+	run("commit", "-q", "--allow-empty", "-m", "first")
+	run("tag", "v1.0.0")
+
+	repository, err := NewLocalGitRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return repository, run("rev-parse", "HEAD")
+}
+
+func TestNewLocalGitRepositoryNotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	if _, err := NewLocalGitRepository(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory that is not a git repository")
+	}
+}
+
+func TestLocalGitRepository(t *testing.T) {
+	repository, hash := newTestGitRepository(t)
+
+	tests := []struct {
+		prefix string
+		want   int
+	}{
+		{prefix: hash, want: 1},
+		{prefix: hash[:7], want: 1},
+		{prefix: "0000000", want: 0},
+	}
+
+	for _, test := range tests {
+		commits, err := repository.ResolveCommit(test.prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(commits) != test.want {
+			t.Errorf("ResolveCommit(%q) = %v, want %d commits", test.prefix, commits, test.want)
+		}
+	}
+
+	date, found, err := repository.TagDate("v1.0.0")
+	if err != nil || !found {
+		t.Fatalf("TagDate(v1.0.0) = %v, %v, %v", date, found, err)
+	}
+	if date.Format("2006-01-02") != "2023-01-02" {
+		t.Errorf("TagDate(v1.0.0) = %v", date)
+	}
+
+	if _, found, err := repository.TagDate("v9.9.9"); err != nil || found {
+		t.Errorf("TagDate(v9.9.9) = %v, %v, want not found without error", found, err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	repository, hash := newTestGitRepository(t)
+
+	yamlText := fmt.Sprintf(`releases:
+  - name: v2.0.0
+    date: 2023-03-01
+    sections:
+      main:
+        changes:
+          fixed:
+            - Fix crash, see $0000000
+  - name: v1.0.0
+    date: 2023-02-01
+    sections:
+      main:
+        changes:
+          added:
+            - Add feature in $%s
+`, hash[:7])
+
+	root, err := ParseYamlNode([]byte(yamlText))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diagnostics, err := Verify(root, repository, DefaultVerifyConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"2:11: release 'v2.0.0' has no tag",
+		"8:15: commit hash '0000000' is not found",
+		"10:11: release date 2023-02-01 does not match the date of tag 'v1.0.0' (2023-01-02)",
+	}
+
+	var got []string
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.String())
+	}
+
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
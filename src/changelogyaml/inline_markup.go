@@ -0,0 +1,131 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var markupLinkRegexp = regexp.MustCompile(`^\[([^\]]+)\]\(([^)\s]+)\)`)
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func runeBefore(text string, position int) rune {
+	if position == 0 {
+		return ' '
+	}
+
+	r, _ := utf8.DecodeLastRuneInString(text[:position])
+
+	return r
+}
+
+func runeAt(text string, position int) rune {
+	if position >= len(text) {
+		return ' '
+	}
+
+	r, _ := utf8.DecodeRuneInString(text[position:])
+
+	return r
+}
+
+// findClosingDelimiter finds the delimiter that closes a bold or italic span. Delimiters within code spans are ignored.
+func findClosingDelimiter(text string, start int, delimiter string) int {
+	position := start
+	for position < len(text) {
+		if text[position] == '`' {
+			if _, end := codeSpanAt(text, position); end >= 0 {
+				position = end
+				continue
+			}
+		}
+
+		if strings.HasPrefix(text[position:], delimiter) && position > start &&
+			!unicode.IsSpace(runeBefore(text, position)) {
+			after := runeAt(text, position+len(delimiter))
+			if delimiter == "**" || (delimiter == "*" && after != '*') || (delimiter == "_" && !isWordRune(after)) {
+				return position
+			}
+		}
+
+		position++
+	}
+
+	return -1
+}
+
+// parseInlineMarkup parses the small subset of inline Markdown that is supported in entries and notices:
+// `code`, **bold**, _italic_ (or *italic*) and [text](url). Everything else is kept as TextNode.
+func parseInlineMarkup(text string) []InlineNode {
+	var nodes []InlineNode
+
+	textStart := 0
+	position := 0
+
+	flushText := func() {
+		if textStart < position {
+			nodes = append(nodes, TextNode{Text: text[textStart:position]})
+		}
+	}
+
+	for position < len(text) {
+		var node InlineNode
+		end := -1
+
+		switch text[position] {
+		case '`':
+			var code string
+			if code, end = codeSpanAt(text, position); end >= 0 {
+				node = CodeNode{Code: code}
+			}
+		case '[':
+			if parts := markupLinkRegexp.FindStringSubmatch(text[position:]); parts != nil {
+				node = LinkNode{Name: parts[1], URL: parts[2], Children: parseInlineMarkup(parts[1])}
+				end = position + len(parts[0])
+			}
+		case '*', '_':
+			delimiter := text[position : position+1]
+			if delimiter == "*" && strings.HasPrefix(text[position:], "**") {
+				delimiter = "**"
+			}
+
+			contentStart := position + len(delimiter)
+			opens := !unicode.IsSpace(runeAt(text, contentStart)) &&
+				(delimiter != "_" || !isWordRune(runeBefore(text, position)))
+			if opens {
+				if closing := findClosingDelimiter(text, contentStart, delimiter); closing >= 0 {
+					children := parseInlineMarkup(text[contentStart:closing])
+					if delimiter == "**" {
+						node = StrongNode{Children: children}
+					} else {
+						node = EmphasisNode{Children: children}
+					}
+					end = closing + len(delimiter)
+				}
+			}
+		}
+
+		if end < 0 {
+			position++
+			continue
+		}
+
+		flushText()
+		nodes = append(nodes, node)
+		position = end
+		textStart = position
+	}
+
+	flushText()
+
+	return nodes
+}
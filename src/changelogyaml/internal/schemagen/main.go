@@ -0,0 +1,179 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+// schemagen writes the JSON Schema for the changelog YAML format. The structure and the descriptions are taken from
+// the type declarations and their doc comments, so the schema is always in sync with types.go.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+)
+
+type schema map[string]interface{}
+
+type generator struct {
+	structs map[string]*ast.StructType
+	docs    map[string]string
+	// scalarOrObject are the types with an UnmarshalYAML method, that can be written as a plain string
+	scalarOrObject map[string]bool
+	definitions    schema
+}
+
+func docText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
+
+func yamlKey(field *ast.Field, name string) string {
+	if field.Tag != nil {
+		tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("yaml")
+		if key := strings.Split(tag, ",")[0]; key != "" {
+			return key
+		}
+	}
+
+	return strings.ToLower(name)
+}
+
+func (g *generator) typeSchema(expr ast.Expr) schema {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return schema{"type": "string"}
+		case "int", "uint8":
+			return schema{"type": "integer"}
+		case "bool":
+			return schema{"type": "boolean"}
+		}
+
+		if _, isStruct := g.structs[t.Name]; isStruct {
+			g.define(t.Name)
+			return schema{"$ref": "#/$defs/" + t.Name}
+		}
+	case *ast.StarExpr:
+		return g.typeSchema(t.X)
+	case *ast.ArrayType:
+		return schema{"type": "array", "items": g.typeSchema(t.Elt)}
+	case *ast.MapType:
+		return schema{"type": "object", "additionalProperties": g.typeSchema(t.Value)}
+	}
+
+	log.Fatalf("unsupported type %#v", expr)
+
+	return nil
+}
+
+func (g *generator) define(name string) {
+	if _, alreadyDefined := g.definitions[name]; alreadyDefined {
+		return
+	}
+
+	definition := schema{}
+	g.definitions[name] = definition
+
+	properties := schema{}
+	for _, field := range g.structs[name].Fields.List {
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+
+			property := g.typeSchema(field.Type)
+			if description := docText(field.Doc); description != "" {
+				property["description"] = description
+			}
+			properties[yamlKey(field, fieldName.Name)] = property
+		}
+	}
+
+	object := schema{"type": "object", "properties": properties, "additionalProperties": false}
+	if g.scalarOrObject[name] {
+		definition["anyOf"] = []schema{{"type": "string"}, object}
+	} else {
+		for key, value := range object {
+			definition[key] = value
+		}
+	}
+
+	if description := g.docs[name]; description != "" {
+		definition["description"] = description
+	}
+}
+
+func main() {
+	output := flag.String("o", "changelog.schema.json", "output file")
+	flag.Parse()
+
+	g := generator{
+		structs:        make(map[string]*ast.StructType),
+		docs:           make(map[string]string),
+		scalarOrObject: make(map[string]bool),
+		definitions:    schema{},
+	}
+
+	fileSet := token.NewFileSet()
+	for _, filename := range flag.Args() {
+		file, err := parser.ParseFile(fileSet, filename, nil, parser.ParseComments)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, declaration := range file.Decls {
+			switch d := declaration.(type) {
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					typeSpec, isType := spec.(*ast.TypeSpec)
+					if !isType {
+						continue
+					}
+					if structType, isStruct := typeSpec.Type.(*ast.StructType); isStruct {
+						g.structs[typeSpec.Name.Name] = structType
+						g.docs[typeSpec.Name.Name] = docText(d.Doc)
+					}
+				}
+			case *ast.FuncDecl:
+				if d.Name.Name == "UnmarshalYAML" && d.Recv != nil {
+					if star, isStar := d.Recv.List[0].Type.(*ast.StarExpr); isStar {
+						g.scalarOrObject[star.X.(*ast.Ident).Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	root := schema{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     "https://github.com/piot/changelog-yaml/changelog.schema.json",
+		"title":   "changelog.yaml",
+		"$ref":    "#/$defs/ChangelogYaml",
+		"$defs":   g.definitions,
+	}
+	g.define("ChangelogYaml")
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*output, buffer.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
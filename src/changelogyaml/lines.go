@@ -6,55 +6,106 @@
 package changelogyaml
 
 import (
-	"fmt"
-	"io"
+	"regexp"
 )
 
-func convertTextLine(line string, repoShortUrl string, formatter Formatter) (string, error) {
-	line, err := replacePullRequestLink(line, repoShortUrl, formatter)
-	if err != nil {
-		return "", err
+// replaceTextMatches replaces every match of re within the TextNode (also those inside bold, italic and admonitions)
+// with the node returned from convert. Convert gets the match followed by the groups, as in FindStringSubmatch. If
+// convert returns nil, the match is kept as text. Code spans, links that are already created, verbatim and escaped
+// text are never touched.
+func replaceTextMatches(inlines []InlineNode, re *regexp.Regexp,
+	convert func(submatches []string) (InlineNode, error)) ([]InlineNode, error) {
+	var result []InlineNode
+
+	for _, inline := range inlines {
+		switch node := inline.(type) {
+		case TextNode:
+			if node.Verbatim || node.Escaped {
+				result = append(result, node)
+				continue
+			}
+
+			allMatches := re.FindAllStringSubmatchIndex(node.Text, -1)
+			previousMatchPosition := 0
+
+			for _, match := range allMatches {
+				submatches := make([]string, len(match)/2)
+				for group := range submatches {
+					if match[group*2] >= 0 {
+						submatches[group] = node.Text[match[group*2]:match[group*2+1]]
+					}
+				}
+
+				replacement, err := convert(submatches)
+				if err != nil {
+					return nil, err
+				}
+
+				if replacement == nil {
+					continue
+				}
+
+				if previousMatchPosition < match[0] {
+					result = append(result, TextNode{Text: node.Text[previousMatchPosition:match[0]]})
+				}
+
+				result = append(result, replacement)
+				previousMatchPosition = match[1]
+			}
+
+			if previousMatchPosition < len(node.Text) {
+				result = append(result, TextNode{Text: node.Text[previousMatchPosition:]})
+			}
+		case StrongNode:
+			children, err := replaceTextMatches(node.Children, re, convert)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, StrongNode{Children: children})
+		case EmphasisNode:
+			children, err := replaceTextMatches(node.Children, re, convert)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, EmphasisNode{Children: children})
+		case AdmonitionNode:
+			children, err := replaceTextMatches(node.Children, re, convert)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, AdmonitionNode{Type: node.Type, Children: children})
+		default:
+			result = append(result, inline)
+		}
 	}
 
-	line = replaceCommitHashLink(line, repoShortUrl, formatter)
-
-	line = replaceAtProfileLink(line, formatter)
-
-	return line, nil
+	return result, nil
 }
 
-func linesForRepo(repoShortUrl string, strings []string, categoryType CategoryType, formatter Formatter,
-	writer io.Writer) error {
-	for _, line := range strings {
-		categoryInfo := infoFromCategoryName(categoryType)
-		prefix := formatter.Emoji(categoryInfo.EmojiName)
-
-		if categoryType == Breaking {
-			prefix += fmt.Sprintf("[%v]", categoryInfo.Name)
-		}
-
-		line, err := convertTextLine(line, repoShortUrl, formatter)
-		if err != nil {
-			return err
-		}
-
-		completeLine := prefix + " " + line
+func convertTextLine(line string, context *LinkContext) ([]InlineNode, error) {
+	return autolink(parseInlineMarkup(line), context)
+}
 
-		if _, err := fmt.Fprint(writer, formatter.BulletPoint(completeLine)); err != nil {
-			return err
-		}
+func convertNotice(notice string) []InlineNode {
+	if notice == "" {
+		return nil
 	}
 
-	return nil
+	inlines, _ := applyAutolinker(replaceAtProfileLink(parseAdmonition(notice)), emojiAutolinker{}, &LinkContext{})
+
+	return inlines
 }
 
 type LineInfo struct {
 	Category CategoryType
-	Lines    []string
+	Lines    []Entry
 }
 
-func textLinesForTheRepo(repoShortUrl string, repoChanges *Changes, formatter Formatter, writer io.Writer) error {
-	lines := []LineInfo{
+func lineInfosFromChanges(repoChanges *Changes) []LineInfo {
+	return []LineInfo{
 		{Unreleased, repoChanges.Unreleased},
 		{Breaking, repoChanges.Breaking},
 		{Added, repoChanges.Added},
@@ -72,12 +123,26 @@ func textLinesForTheRepo(repoShortUrl string, repoChanges *Changes, formatter Fo
 		{Performance, repoChanges.Performance},
 		{Style, repoChanges.Style},
 	}
+}
 
-	for _, line := range lines {
-		if err := linesForRepo(repoShortUrl, line.Lines, line.Category, formatter, writer); err != nil {
-			return err
+func entriesForTheRepo(context *LinkContext, repoChanges *Changes) ([]EntryNode, error) {
+	var entries []EntryNode
+
+	for _, lineInfo := range lineInfosFromChanges(repoChanges) {
+		for _, line := range lineInfo.Lines {
+			inlines, err := convertTextLine(line.Text, context)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, EntryNode{
+				Category:  lineInfo.Category,
+				Raw:       line.Text,
+				Inlines:   inlines,
+				Migration: line.Migration,
+			})
 		}
 	}
 
-	return nil
+	return entries, nil
 }
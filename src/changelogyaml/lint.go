@@ -0,0 +1,225 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	LintImperative     = "imperative"
+	LintTrailingPeriod = "trailing-period"
+	LintMaxLength      = "max-length"
+	LintCapital        = "capital"
+	LintDuplicate      = "duplicate"
+	LintBareURL        = "bare-url"
+	LintStutter        = "stutter"
+)
+
+// LintRules lists the names of all lint rules.
+var LintRules = []string{
+	LintImperative,
+	LintTrailingPeriod,
+	LintMaxLength,
+	LintCapital,
+	LintDuplicate,
+	LintBareURL,
+	LintStutter,
+}
+
+// LintConfig selects the lint rules and their settings.
+type LintConfig struct {
+	// Disabled has the names of the rules that should not be checked.
+	Disabled map[string]bool
+
+	// RequirePeriod makes the trailing-period rule require a period instead of forbidding it.
+	RequirePeriod bool
+
+	// MaxLength is the maximum number of characters in an entry.
+	MaxLength int
+}
+
+// IsLintRule returns true if the name is one of LintRules.
+func IsLintRule(name string) bool {
+	for _, rule := range LintRules {
+		if rule == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func DefaultLintConfig() LintConfig {
+	return LintConfig{
+		Disabled:  make(map[string]bool),
+		MaxLength: 120,
+	}
+}
+
+type LintIssue struct {
+	Rule    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%d:%d: %s (%s)", i.Line, i.Column, i.Message, i.Rule)
+}
+
+var (
+	lintWordRegexp        = regexp.MustCompile(`[\p{L}\p{N}'-]+`)
+	lintGithubURLRegexp   = regexp.MustCompile(`https://github\.com/[\w.-]+/[\w.-]+/(pull|issues|commit)/(\w+)`)
+	notImperativeSuffixes = []string{"ed", "ing"}
+	imperativeExceptions  = map[string]bool{
+		"need": true, "feed": true, "seed": true, "speed": true, "embed": true, "shed": true, "proceed": true,
+		"exceed": true, "succeed": true, "bring": true, "string": true, "ring": true, "sing": true, "spring": true,
+	}
+	thirdPersonVerbs = map[string]bool{
+		"adds": true, "fixes": true, "removes": true, "changes": true, "updates": true, "improves": true,
+		"deprecates": true, "refactors": true, "moves": true, "renames": true, "uses": true, "makes": true,
+		"allows": true, "supports": true, "introduces": true, "replaces": true, "bumps": true, "implements": true,
+	}
+)
+
+func firstWord(text string) string {
+	trimmed := strings.TrimSpace(text)
+	end := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if end < 0 {
+		return trimmed
+	}
+
+	return trimmed[:end]
+}
+
+func lintImperative(text string) string {
+	word := strings.ToLower(firstWord(text))
+	if word == "" || imperativeExceptions[word] {
+		return ""
+	}
+
+	for _, suffix := range notImperativeSuffixes {
+		if strings.HasSuffix(word, suffix) && len(word) > len(suffix)+2 {
+			return fmt.Sprintf("use imperative mood, '%s' does not look like an imperative verb", firstWord(text))
+		}
+	}
+
+	if thirdPersonVerbs[word] {
+		return fmt.Sprintf("use imperative mood, not '%s'", firstWord(text))
+	}
+
+	return ""
+}
+
+func lintTrailingPeriod(text string, requirePeriod bool) string {
+	trimmed := strings.TrimSpace(text)
+	hasPeriod := strings.HasSuffix(trimmed, ".") && !strings.HasSuffix(trimmed, "...")
+
+	if requirePeriod && !hasPeriod {
+		return "entry must end with a period"
+	}
+
+	if !requirePeriod && hasPeriod {
+		return "entry should not end with a period"
+	}
+
+	return ""
+}
+
+func lintCapital(text string) string {
+	trimmed := strings.TrimSpace(text)
+	first, _ := utf8.DecodeRuneInString(trimmed)
+	if unicode.IsLetter(first) && !unicode.IsUpper(first) {
+		return "entry should start with a capital letter"
+	}
+
+	return ""
+}
+
+func lintBareURL(text string) string {
+	parts := lintGithubURLRegexp.FindStringSubmatch(text)
+	if parts == nil {
+		return ""
+	}
+
+	if parts[1] == "commit" {
+		return fmt.Sprintf("use '$%s' instead of the commit URL", parts[2])
+	}
+
+	return fmt.Sprintf("use '#%s' instead of the URL", parts[2])
+}
+
+func lintStutter(text string) string {
+	words := lintWordRegexp.FindAllString(text, -1)
+	for i := 1; i < len(words); i++ {
+		if strings.EqualFold(words[i-1], words[i]) && !unicode.IsDigit([]rune(words[i])[0]) {
+			return fmt.Sprintf("repeated word '%s %s'", words[i-1], words[i])
+		}
+	}
+
+	return ""
+}
+
+// LintChanges checks every change line with the rules that are enabled in the config. Issues are sorted in file order.
+func LintChanges(changes []ChangeNode, config LintConfig) []LintIssue {
+	var issues []LintIssue
+
+	type duplicateKey struct {
+		release   int
+		groupKind GroupKind
+		group     string
+		category  CategoryType
+		text      string
+	}
+	seen := make(map[duplicateKey]int)
+
+	for _, change := range changes {
+		report := func(rule string, message string) {
+			if message != "" && !config.Disabled[rule] {
+				issues = append(issues, LintIssue{Rule: rule, Line: change.Line, Column: change.Column,
+					Message: message})
+			}
+		}
+
+		report(LintImperative, lintImperative(change.Text))
+		report(LintTrailingPeriod, lintTrailingPeriod(change.Text, config.RequirePeriod))
+		report(LintCapital, lintCapital(change.Text))
+		report(LintBareURL, lintBareURL(change.Text))
+		report(LintStutter, lintStutter(change.Text))
+
+		if config.MaxLength > 0 && utf8.RuneCountInString(change.Text) > config.MaxLength {
+			report(LintMaxLength, fmt.Sprintf("entry is %d characters, maximum is %d",
+				utf8.RuneCountInString(change.Text), config.MaxLength))
+		}
+
+		key := duplicateKey{
+			release:   change.ReleaseIndex,
+			groupKind: change.GroupKind,
+			group:     change.GroupKey,
+			category:  change.Category,
+			text:      strings.ToLower(strings.TrimSpace(change.Text)),
+		}
+		if previousLine, found := seen[key]; found {
+			report(LintDuplicate, fmt.Sprintf("duplicate of the entry on line %d", previousLine))
+		} else {
+			seen[key] = change.Line
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	return issues
+}
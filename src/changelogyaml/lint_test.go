@@ -0,0 +1,84 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import "testing"
+
+func TestLintDuplicates(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want int
+	}{
+		{"same category", `
+repos:
+  main:
+    repo: piot/main
+releases:
+  - name: v1.0.0
+    sections:
+      main:
+        changes:
+          added:
+            - Add a feature
+            - Add a Feature
+`, 1},
+		{"different categories", `
+releases:
+  - name: v1.0.0
+    sections:
+      main:
+        changes:
+          added:
+            - Add a feature
+          fixed:
+            - Add a feature
+`, 0},
+		{"section and repo with the same name", `
+repos:
+  main:
+    repo: piot/main
+releases:
+  - name: v1.0.0
+    sections:
+      main:
+        changes:
+          added:
+            - Add a feature
+    repos:
+      main:
+        added:
+          - Add a feature
+`, 0},
+	}
+
+	config := DefaultLintConfig()
+	for _, rule := range LintRules {
+		config.Disabled[rule] = rule != LintDuplicate
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := ParseYamlNode([]byte(test.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if issues := LintChanges(ChangeNodes(root), config); len(issues) != test.want {
+				t.Errorf("got %v, want %d issues", issues, test.want)
+			}
+		})
+	}
+}
+
+func TestIsLintRule(t *testing.T) {
+	if !IsLintRule(LintCapital) {
+		t.Error("capital should be a rule")
+	}
+	if IsLintRule("captial") {
+		t.Error("captial should not be a rule")
+	}
+}
@@ -0,0 +1,253 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type loader struct {
+	// loading has the absolute paths of the files that are currently loaded, to detect include cycles.
+	loading map[string]bool
+}
+
+func (l *loader) load(filename string) (*ChangelogYaml, error) {
+	absoluteFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.loading[absoluteFilename] {
+		return nil, fmt.Errorf("'%s' includes itself", filename)
+	}
+
+	l.loading[absoluteFilename] = true
+	defer delete(l.loading, absoluteFilename)
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var c ChangelogYaml
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if err := l.resolve(&c, filepath.Dir(filename)); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return &c, nil
+}
+
+func (l *loader) resolve(c *ChangelogYaml, baseDirectory string) error {
+	for _, include := range c.Include {
+		included, err := l.load(filepath.Join(baseDirectory, include))
+		if err != nil {
+			return err
+		}
+
+		c.Merge(included)
+	}
+
+	c.Include = nil
+
+	for repoKey, definition := range c.Repos {
+		if definition.Changelog == "" {
+			continue
+		}
+
+		repoChangelog, err := l.load(filepath.Join(baseDirectory, definition.Changelog))
+		if err != nil {
+			return err
+		}
+
+		if err := aggregateRepoChangelog(c, repoKey, repoChangelog); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Merge adds the releases and autolink rules of other after the ones in c, and the repo definitions and category
+// overrides that are not already defined. References is taken from other if it is not set in c.
+func (c *ChangelogYaml) Merge(other *ChangelogYaml) {
+	c.Releases = append(c.Releases, other.Releases...)
+	c.Autolinks = append(c.Autolinks, other.Autolinks...)
+
+	if c.References == "" {
+		c.References = other.References
+	}
+
+	for key, override := range other.Categories {
+		if _, alreadyDefined := c.Categories[key]; alreadyDefined {
+			continue
+		}
+
+		if c.Categories == nil {
+			c.Categories = make(map[string]CategoryOverride)
+		}
+		c.Categories[key] = override
+	}
+
+	for key, definition := range other.Repos {
+		if _, alreadyDefined := c.Repos[key]; alreadyDefined {
+			continue
+		}
+
+		if c.Repos == nil {
+			c.Repos = make(map[string]RepoDefinition)
+		}
+		c.Repos[key] = definition
+	}
+}
+
+// appendChanges adds all entries in source to target. Security is not a category, but is kept as it is.
+func appendChanges(target *Changes, source *Changes) {
+	for _, lineInfo := range lineInfosFromChanges(source) {
+		entries := target.entriesForCategory(lineInfo.Category)
+		*entries = append(*entries, lineInfo.Lines...)
+	}
+
+	target.Security = append(target.Security, source.Security...)
+}
+
+// releaseChanges returns all changes in all sections and repos of a release in a repo changelog.
+func releaseChanges(release *Release) Changes {
+	var changes Changes
+
+	for _, sectionName := range sortedSectionNames(release) {
+		section := release.Sections[sectionName]
+		appendChanges(&changes, &section.Changes)
+	}
+
+	for _, repoName := range sortedRepoNames(release) {
+		repoChanges := release.Repos[repoName]
+		appendChanges(&changes, &repoChanges)
+	}
+
+	return changes
+}
+
+func isEmptyChanges(changes *Changes) bool {
+	if len(changes.Security) > 0 {
+		return false
+	}
+
+	for _, lineInfo := range lineInfosFromChanges(changes) {
+		if len(lineInfo.Lines) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// aggregateRepoChanges adds the changes from the repo changelog to Release.Repos of every release in the umbrella
+// changelog. Releases are expected to be sorted with the newest first. Changes after the newest release go to the
+// unreleased release, if there is one.
+func aggregateRepoChangelog(c *ChangelogYaml, repoKey string, repoChangelog *ChangelogYaml) error {
+	for index := range c.Releases {
+		release := &c.Releases[index]
+
+		var matched []Release
+		if version, isMapped := release.Versions[repoKey]; isMapped {
+			repoReleaseIndex, err := findReleaseIndex(repoChangelog.Releases, version)
+			if err != nil {
+				return fmt.Errorf("release %s, repo %s: %w", release.Name, repoKey, err)
+			}
+			matched = append(matched, repoChangelog.Releases[repoReleaseIndex])
+		} else {
+			var previousDate time.Time
+			if index+1 < len(c.Releases) {
+				var err error
+				if previousDate, err = releaseDate(&c.Releases[index+1]); err != nil {
+					return err
+				}
+			}
+
+			date, err := releaseDate(release)
+			if err != nil {
+				return err
+			}
+
+			for _, repoRelease := range repoChangelog.Releases {
+				repoDate, err := releaseDate(&repoRelease)
+				if err != nil {
+					return fmt.Errorf("repo %s: %w", repoKey, err)
+				}
+
+				// Releases without a date are unreleased, and come after all releases. Dates are compared by day, since
+				// the umbrella release often only has a date, and the repo releases a time as well.
+				isAfterPrevious := repoDate.IsZero() || dateOnly(repoDate).After(dateOnly(previousDate))
+				isUntilRelease := date.IsZero() || (!repoDate.IsZero() && !dateOnly(repoDate).After(dateOnly(date)))
+				if isAfterPrevious && isUntilRelease {
+					matched = append(matched, repoRelease)
+				}
+			}
+		}
+
+		changes := release.Repos[repoKey]
+		for _, repoRelease := range matched {
+			repoChanges := releaseChanges(&repoRelease)
+			appendChanges(&changes, &repoChanges)
+		}
+
+		if isEmptyChanges(&changes) {
+			continue
+		}
+
+		if release.Repos == nil {
+			release.Repos = make(map[string]Changes)
+		}
+		release.Repos[repoKey] = changes
+	}
+
+	return nil
+}
+
+// LoadChangelog reads the changelog file, with all included files and repo changelogs.
+func LoadChangelog(filename string) (*ChangelogYaml, error) {
+	l := loader{loading: make(map[string]bool)}
+
+	return l.load(filename)
+}
+
+// ReadChangelog reads a changelog from the reader, e.g. stdin. Included files and repo changelogs are relative to
+// baseDirectory.
+func ReadChangelog(reader io.Reader, baseDirectory string) (*ChangelogYaml, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var c ChangelogYaml
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	if err := c.Resolve(baseDirectory); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Resolve reads the included files and the repo changelogs, with paths relative to baseDirectory. It is done by
+// LoadChangelog, and only needs to be called if the changelog was read in another way, e.g. with ReadYaml.
+func (c *ChangelogYaml) Resolve(baseDirectory string) error {
+	l := loader{loading: make(map[string]bool)}
+
+	return l.resolve(c, baseDirectory)
+}
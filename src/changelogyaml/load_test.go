@@ -0,0 +1,106 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	directory := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return directory
+}
+
+func TestAggregateKeepsAllCategories(t *testing.T) {
+	directory := writeTestFiles(t, map[string]string{
+		"changelog.yaml": `
+repo: piot/umbrella
+repos:
+  lib:
+    repo: piot/lib
+    changelog: lib.yaml
+releases:
+  - name: v1.0.0
+    date: 2023-06-22
+`,
+		"lib.yaml": `
+repo: piot/lib
+releases:
+  - name: v0.1.0
+    date: 2023-06-01
+    sections:
+      main:
+        changes:
+          security:
+            - Fix the buffer overflow
+          fixed:
+            - Fix the crash
+`,
+	})
+
+	c, err := LoadChangelog(filepath.Join(directory, "changelog.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes := c.Releases[0].Repos["lib"]
+	if len(changes.Security) != 1 || len(changes.Fixed) != 1 {
+		t.Errorf("got %+v, want one security and one fixed entry", changes)
+	}
+}
+
+func TestIncludeMergesSettings(t *testing.T) {
+	directory := writeTestFiles(t, map[string]string{
+		"changelog.yaml": `
+repo: piot/nimble
+categories:
+  fixed:
+    emoji: wrench
+include:
+  - old.yaml
+releases:
+  - name: v2.0.0
+    date: 2023-06-22
+`,
+		"old.yaml": `
+references: issues
+categories:
+  fixed:
+    emoji: bug
+  added:
+    emoji: sparkles
+releases:
+  - name: v1.0.0
+    date: 2023-06-01
+`,
+	})
+
+	c, err := LoadChangelog(filepath.Join(directory, "changelog.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Releases) != 2 {
+		t.Errorf("got %d releases, want 2", len(c.Releases))
+	}
+	if c.References != IssueReferences {
+		t.Errorf("got references %q, want %q", c.References, IssueReferences)
+	}
+	if c.Categories["fixed"].Emoji != "wrench" || c.Categories["added"].Emoji != "sparkles" {
+		t.Errorf("got categories %+v, want fixed from the including file and added from the included file",
+			c.Categories)
+	}
+}
@@ -11,22 +11,110 @@ import (
 )
 
 type MarkdownFormatter struct {
+	EmojiStyle EmojiStyle
 }
 
+// markdownTextEscaper backslash escapes the ASCII punctuation that can start inline markup, HTML or a table cell.
+var markdownTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`|`, `\|`,
+	`~`, `\~`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`!`, `\!`,
+)
+
+var markdownURLEscaper = strings.NewReplacer(
+	` `, `%20`,
+	`(`, `%28`,
+	`)`, `%29`,
+	`<`, `%3C`,
+	`>`, `%3E`,
+)
+
 func (m *MarkdownFormatter) Heading(level int, header string) string {
 	return strings.Repeat("#", level) + " " + header + "\n\n"
 }
 
+func (m *MarkdownFormatter) Text(text string) string {
+	return markdownTextEscaper.Replace(text)
+}
+
+func (m *MarkdownFormatter) Verbatim(text string) string {
+	return text
+}
+
+func (m *MarkdownFormatter) Code(code string) string {
+	return wrapCodeSpan(code)
+}
+
 func (m *MarkdownFormatter) BulletPoint(text string) string {
 	return "* " + text + "\n"
 }
 
+func (m *MarkdownFormatter) ListItem(depth int, text string) string {
+	return strings.Repeat("  ", depth) + "* " + text + "\n"
+}
+
+func (m *MarkdownFormatter) Anchor(id string) string {
+	return fmt.Sprintf("<a id=\"%s\"></a>\n\n", id)
+}
+
+func (m *MarkdownFormatter) InternalLink(name string, id string) string {
+	return fmt.Sprintf("[%s](#%s)", m.Text(name), id)
+}
+
+func (m *MarkdownFormatter) BeginCollapsible(summary string) string {
+	return fmt.Sprintf("<details>\n<summary>%s</summary>\n\n", summary)
+}
+
+func (m *MarkdownFormatter) EndCollapsible() string {
+	return "</details>\n\n"
+}
+
 func (m *MarkdownFormatter) Emoji(name string) string {
+	switch m.EmojiStyle {
+	case NoEmoji:
+		return ""
+	case UnicodeEmoji:
+		if unicode, found := EmojiUnicode(name); found {
+			return unicode
+		}
+	}
+
 	return ":" + name + ":"
 }
 
 func (m *MarkdownFormatter) Link(name string, link string) string {
-	return fmt.Sprintf("[%s](%s)", name, link)
+	return fmt.Sprintf("[%s](%s)", name, markdownURLEscaper.Replace(link))
+}
+
+func (m *MarkdownFormatter) Image(alt string, url string) string {
+	return fmt.Sprintf("![%s](%s)", m.Text(alt), markdownURLEscaper.Replace(url))
+}
+
+func (m *MarkdownFormatter) CodeBlock(language string, code string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	return fence + language + "\n" + strings.TrimRight(code, "\n") + "\n" + fence + "\n\n"
+}
+
+func (m *MarkdownFormatter) Strong(text string) string {
+	return "**" + text + "**"
+}
+
+func (m *MarkdownFormatter) Emphasis(text string) string {
+	return "_" + text + "_"
 }
 
 func AdmonitionTypeToGithubName(admonitionType AdmonitionType) string {
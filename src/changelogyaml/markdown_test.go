@@ -0,0 +1,83 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"testing"
+)
+
+func renderMarkdownLine(t *testing.T, line string) string {
+	t.Helper()
+
+	inlines, err := convertTextLine(line, testLinkContext())
+	if err != nil {
+		t.Fatalf("%q: %v", line, err)
+	}
+
+	return RenderInlines(inlines, &MarkdownFormatter{})
+}
+
+func TestMarkdownInlines(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"text is escaped", "Use a_b and <T>", `Use a\_b and \<T\>`},
+		{"bare URL is not escaped", "See https://github.com/piot/nimble/pull/3#issuecomment-1",
+			"See https://github.com/piot/nimble/pull/3#issuecomment-1"},
+		{"email is not escaped", "Mail first_last@example.com", "Mail first_last@example.com"},
+		{"escaped reference stays escaped", `Fix \#12`, `Fix \#12`},
+		{"link text markup", "See [the **new** `api`](https://example.com)", "See [the **new** `api`](https://example.com)"},
+		{"link text is escaped", "See [a_b](https://example.com)", `See [a\_b](https://example.com)`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := renderMarkdownLine(t, test.line); got != test.want {
+				t.Errorf("%q: got %q, want %q", test.line, got, test.want)
+			}
+		})
+	}
+}
+
+func TestAsciiDocLinkText(t *testing.T) {
+	inlines := parseInlineMarkup("See [the _new_ api](https://example.com)")
+
+	want := "See link:https://example.com[the __new__ api]"
+	if got := RenderInlines(inlines, &AsciiDocFormatter{}); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestEmojiInEntries(t *testing.T) {
+	inlines, err := convertTextLine("Ship it :rocket:", testLinkContext())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		want      string
+	}{
+		{"shortcode", &MarkdownFormatter{EmojiStyle: ShortcodeEmoji}, "Ship it :rocket:"},
+		{"unicode", &MarkdownFormatter{EmojiStyle: UnicodeEmoji}, "Ship it \U0001F680"},
+		{"none", &MarkdownFormatter{EmojiStyle: NoEmoji}, "Ship it "},
+		{"asciidoc", &AsciiDocFormatter{}, "Ship it &#x1F680;"},
+	}
+
+	for _, test := range tests {
+		if got := RenderInlines(inlines, test.formatter); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+
+	notice := RenderInlines(convertNotice("NOTE: Ship it :rocket:"), &MarkdownFormatter{EmojiStyle: UnicodeEmoji})
+	if want := "> [!NOTE]\\\n> Ship it \U0001F680"; notice != want {
+		t.Errorf("notice: got %q, want %q", notice, want)
+	}
+}
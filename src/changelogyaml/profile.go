@@ -10,26 +10,26 @@ import (
 	"regexp"
 )
 
-func replaceAtProfileLink(line string, formatter Formatter) string {
-	re := regexp.MustCompile(`@[a-z\d-]*`)
-	allMatches := re.FindAllStringIndex(line, -1)
-
-	lineToPrint := ""
-	previousMatchPosition := 0
-
-	if len(allMatches) > 0 {
-		for _, match := range allMatches {
-			usernameString := line[match[0]+1 : match[1]]
-			usernameProfileLink := fmt.Sprintf("%s%v", githubUrlPrefix, usernameString)
-			usernameProfileLinkComplete := formatter.Link("@"+usernameString, usernameProfileLink)
-			lineToPrint += line[previousMatchPosition:match[0]] + usernameProfileLinkComplete
-			previousMatchPosition = match[1]
-		}
-
-		lineToPrint += line[previousMatchPosition:]
-	} else {
-		lineToPrint = line
-	}
-
-	return lineToPrint
+// profileRegexp matches GitHub usernames: letters, digits and single hyphens, not starting with a hyphen. The `@` must
+// not be preceded by a letter or digit.
+var profileRegexp = regexp.MustCompile(`\B@[A-Za-z\d](?:-?[A-Za-z\d]){0,38}\b`)
+
+// profileAutolinker links `@username` to the GitHub profile.
+type profileAutolinker struct{}
+
+func (profileAutolinker) Pattern() *regexp.Regexp {
+	return profileRegexp
+}
+
+func (profileAutolinker) Link(submatches []string, _ *LinkContext) (InlineNode, error) {
+	usernameString := submatches[0][1:]
+	usernameProfileLink := fmt.Sprintf("%s%v", githubUrlPrefix, usernameString)
+
+	return LinkNode{Kind: ProfileLink, Name: "@" + usernameString, URL: usernameProfileLink}, nil
+}
+
+func replaceAtProfileLink(inlines []InlineNode) []InlineNode {
+	replaced, _ := applyAutolinker(tokenizeLine(inlines), profileAutolinker{}, &LinkContext{})
+
+	return replaced
 }
@@ -11,33 +11,35 @@ import (
 	"strconv"
 )
 
-func replacePullRequestLink(line string, repoShortUrl string, formatter Formatter) (string, error) {
-	re := regexp.MustCompile(`#\d*`)
-	allMatches := re.FindAllStringIndex(line, -1)
-
-	lineToPrint := ""
-	previousMatchPosition := 0
-
-	if len(allMatches) > 0 {
-		for _, match := range allMatches {
-			matchString := line[match[0]+1 : match[1]]
-
-			pullRequestID, err := strconv.Atoi(matchString)
-			if err != nil {
-				return "", err
-			}
-
-			suffix := fmt.Sprintf("pull/%v", pullRequestID)
-			pullRequestLink := fmt.Sprintf("%s%v/%v", githubUrlPrefix, repoShortUrl, suffix)
-			pullRequestCompleteLink := formatter.Link(fmt.Sprintf("#%v", pullRequestID), pullRequestLink)
-			lineToPrint += line[previousMatchPosition:match[0]] + pullRequestCompleteLink
-			previousMatchPosition = match[1]
-		}
-
-		lineToPrint += line[previousMatchPosition:]
-	} else {
-		lineToPrint = line
+// pullRequestRegexp matches `#123`, but not `#` alone or `C#1`.
+var pullRequestRegexp = regexp.MustCompile(`\B#\d+\b`)
+
+// referenceLink returns a link to the pull request, or the issue, with the number in the repo. Numbers that are too
+// large to be a pull request or issue are kept as text.
+func referenceLink(name string, repoShortUrl string, issue bool, number string) (InlineNode, error) {
+	id, err := strconv.Atoi(number)
+	if err != nil {
+		return nil, nil
 	}
 
-	return lineToPrint, nil
+	if issue {
+		return LinkNode{Kind: IssueLink, Name: name, URL: fmt.Sprintf("%s%v/issues/%v", githubUrlPrefix, repoShortUrl,
+			id)}, nil
+	}
+
+	return LinkNode{Kind: PullRequestLink, Name: name, URL: fmt.Sprintf("%s%v/pull/%v", githubUrlPrefix, repoShortUrl,
+		id)}, nil
+}
+
+// pullRequestAutolinker links `#123` to the pull request, or issue, in the current repo.
+type pullRequestAutolinker struct{}
+
+func (pullRequestAutolinker) Pattern() *regexp.Regexp {
+	return pullRequestRegexp
+}
+
+// Link keeps the text as it is written as the name, e.g. `#007`, and leaves the parsing of the number to
+// referenceLink.
+func (pullRequestAutolinker) Link(submatches []string, context *LinkContext) (InlineNode, error) {
+	return referenceLink(submatches[0], context.Repo, context.IssueReferences, submatches[0][1:])
 }
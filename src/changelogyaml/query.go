@@ -0,0 +1,281 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Query selects releases and entries from a changelog. Empty fields do not filter.
+type Query struct {
+	// From and To is an inclusive range of release names.
+	From string
+	To   string
+
+	// Since only keeps releases with a date on or after it, e.g. `2023-06-01`, and the unreleased release.
+	Since string
+
+	Categories []CategoryType
+
+	// Repos are repo keys. If only Repos is set, all sections are removed.
+	Repos []string
+
+	// Sections are section names. If only Sections is set, all repos are removed.
+	Sections []string
+
+	// Authors only keeps entries that mention one of the users, with or without the `@`. Only mentions that are
+	// written as profile links count, the same as for the contributors in Statistics.
+	Authors []string
+}
+
+// ParseCategory returns the category for a name as written in the YAML file, e.g. `breaking`.
+func ParseCategory(name string) (CategoryType, error) {
+	category, found := categoryFromKey(strings.TrimSpace(name))
+	if !found {
+		return 0, fmt.Errorf("unknown category '%s'", name)
+	}
+
+	return category, nil
+}
+
+func (c *Changes) entriesForCategory(category CategoryType) *[]Entry {
+	switch category {
+	case Added:
+		return &c.Added
+	case Changed:
+		return &c.Changed
+	case Fixed:
+		return &c.Fixed
+	case Workaround:
+		return &c.Workaround
+	case Performance:
+		return &c.Performance
+	case Tests:
+		return &c.Tests
+	case Removed:
+		return &c.Removed
+	case Improved:
+		return &c.Improved
+	case Breaking:
+		return &c.Breaking
+	case Deprecated:
+		return &c.Deprecated
+	case Refactored:
+		return &c.Refactored
+	case Experimental:
+		return &c.Experimental
+	case Docs:
+		return &c.Docs
+	case Noted:
+		return &c.Noted
+	case Style:
+		return &c.Style
+	case Unreleased:
+		return &c.Unreleased
+	}
+
+	panic(fmt.Errorf("unknown category '%v'", category))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorNames returns the usernames in Authors in lower case and without the `@`, or nil if all entries are kept.
+func (q *Query) authorNames() map[string]bool {
+	if len(q.Authors) == 0 {
+		return nil
+	}
+
+	names := make(map[string]bool, len(q.Authors))
+	for _, author := range q.Authors {
+		names[strings.ToLower(strings.TrimPrefix(author, "@"))] = true
+	}
+
+	return names
+}
+
+// mentionsAuthor returns true if the text has a profile link to one of the authors. The text is autolinked the same
+// way as when it is written, so `@piot` in a code span, in an email address or escaped as `\@piot` is not a mention.
+func mentionsAuthor(text string, authors map[string]bool, context *LinkContext) (bool, error) {
+	inlines, err := convertTextLine(text, context)
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range mentionedProfiles(inlines, nil) {
+		if authors[strings.ToLower(strings.TrimPrefix(name, "@"))] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// filterChanges returns the changes that match the query, and if there were any.
+func (q *Query) filterChanges(changes *Changes, authors map[string]bool, context *LinkContext) (Changes, bool, error) {
+	var filtered Changes
+	found := false
+
+	for _, lineInfo := range lineInfosFromChanges(changes) {
+		if len(q.Categories) > 0 && !containsCategory(q.Categories, lineInfo.Category) {
+			continue
+		}
+
+		target := filtered.entriesForCategory(lineInfo.Category)
+		for _, entry := range lineInfo.Lines {
+			if authors != nil {
+				mentioned, err := mentionsAuthor(entry.Text, authors, context)
+				if err != nil {
+					return Changes{}, false, err
+				}
+				if !mentioned {
+					continue
+				}
+			}
+			*target = append(*target, entry)
+			found = true
+		}
+	}
+
+	return filtered, found, nil
+}
+
+func containsCategory(categories []CategoryType, category CategoryType) bool {
+	for _, c := range categories {
+		if c == category {
+			return true
+		}
+	}
+
+	return false
+}
+
+func findReleaseIndex(releases []Release, name string) (int, error) {
+	for index, release := range releases {
+		if release.Name == name {
+			return index, nil
+		}
+	}
+
+	return -1, fmt.Errorf("unknown release '%s'", name)
+}
+
+// QueryChangelog returns a copy of the changelog that only has the releases and entries that match the query.
+// Releases without any matching entries are removed. The result can be written with WriteDocument.
+func QueryChangelog(root *ChangelogYaml, query Query) (*ChangelogYaml, error) {
+	result := *root
+	result.Releases = nil
+
+	// Releases are sorted with the newest first
+	newestIndex := 0
+	oldestIndex := len(root.Releases) - 1
+
+	var since time.Time
+	if query.Since != "" {
+		var err error
+		if since, err = ParseReleaseDate(query.Since); err != nil {
+			return nil, fmt.Errorf("since: %w", err)
+		}
+	}
+
+	var err error
+	if query.To != "" {
+		if newestIndex, err = findReleaseIndex(root.Releases, query.To); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.From != "" {
+		if oldestIndex, err = findReleaseIndex(root.Releases, query.From); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.From != "" && query.To != "" && oldestIndex < newestIndex {
+		return nil, fmt.Errorf("'%s' is older than '%s'", query.To, query.From)
+	}
+
+	keepSections := len(query.Sections) > 0 || len(query.Repos) == 0
+	keepRepos := len(query.Repos) > 0 || len(query.Sections) == 0
+	authors := query.authorNames()
+	context, err := newLinkContext(root)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := newestIndex; index <= oldestIndex; index++ {
+		release := root.Releases[index]
+		if query.Since != "" {
+			date, err := releaseDate(&release)
+			if err != nil {
+				return nil, err
+			}
+
+			// Unreleased changes are always newer than since
+			if !date.IsZero() && date.Before(since) {
+				continue
+			}
+		}
+
+		filteredRelease := release
+		filteredRelease.Sections = nil
+		filteredRelease.Repos = nil
+
+		if keepSections {
+			for name, section := range release.Sections {
+				if len(query.Sections) > 0 && !contains(query.Sections, name) {
+					continue
+				}
+
+				changes, found, err := query.filterChanges(&section.Changes, authors, context)
+				if err != nil {
+					return nil, err
+				}
+				if found {
+					if filteredRelease.Sections == nil {
+						filteredRelease.Sections = make(map[string]Section)
+					}
+					filteredRelease.Sections[name] = Section{Order: section.Order, Notice: section.Notice,
+						Changes: changes}
+				}
+			}
+		}
+
+		if keepRepos {
+			for name, repoChanges := range release.Repos {
+				if len(query.Repos) > 0 && !contains(query.Repos, name) {
+					continue
+				}
+
+				changes, found, err := query.filterChanges(&repoChanges, authors, context)
+				if err != nil {
+					return nil, err
+				}
+				if found {
+					if filteredRelease.Repos == nil {
+						filteredRelease.Repos = make(map[string]Changes)
+					}
+					filteredRelease.Repos[name] = changes
+				}
+			}
+		}
+
+		if len(filteredRelease.Sections) > 0 || len(filteredRelease.Repos) > 0 {
+			result.Releases = append(result.Releases, filteredRelease)
+		}
+	}
+
+	return &result, nil
+}
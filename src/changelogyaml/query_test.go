@@ -0,0 +1,175 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+const queryTestChangelog = `
+repo: piot/nimble
+references: issues
+categories:
+  fixed:
+    emoji: wrench
+autolinks:
+  - pattern: PROJ-\d+
+    url: https://jira.example.com/browse/{0}
+repos:
+  clog:
+    repo: piot/clog
+releases:
+  - name: v2.0.0
+    date: 2023-07-01
+    codename: Hedgehog
+    tag: release-2.0.0
+    prerelease: true
+    yanked: true
+    yankedReason: Corrupts the save files
+    links:
+      - name: Migration guide
+        url: https://example.com/migrate
+    assets:
+      - name: nimble.zip
+        url: https://example.com/nimble.zip
+        checksum: sha256:abc
+    notice: Read the migration guide
+    sections:
+      main:
+        changes:
+          fixed:
+            - Fix the crash
+          added:
+            - Add a feature
+`
+
+func readQueryTestChangelog(t *testing.T) *ChangelogYaml {
+	t.Helper()
+
+	root, err := ReadChangelog(bytes.NewBufferString(queryTestChangelog), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return root
+}
+
+// TestQueryKeepsFields checks that everything but the filtered changes is copied, also fields that are added to
+// ChangelogYaml and Release after QueryChangelog was written.
+func TestQueryKeepsFields(t *testing.T) {
+	root := readQueryTestChangelog(t)
+
+	result, err := QueryChangelog(root, Query{Categories: []CategoryType{Fixed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Releases) != 1 {
+		t.Fatalf("got %d releases, want 1", len(result.Releases))
+	}
+
+	original := root.Releases[0]
+	filtered := result.Releases[0]
+
+	if len(filtered.Sections["main"].Changes.Added) != 0 || len(filtered.Sections["main"].Changes.Fixed) != 1 {
+		t.Errorf("only the fixed entry should be kept, got %+v", filtered.Sections["main"].Changes)
+	}
+
+	original.Sections = nil
+	filtered.Sections = nil
+	if !reflect.DeepEqual(original, filtered) {
+		t.Errorf("release fields were not kept:\ngot  %+v\nwant %+v", filtered, original)
+	}
+
+	expected := *root
+	expected.Releases = nil
+	actual := *result
+	actual.Releases = nil
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("changelog fields were not kept:\ngot  %+v\nwant %+v", actual, expected)
+	}
+}
+
+func TestQueryFromNewerThanTo(t *testing.T) {
+	root := &ChangelogYaml{Releases: []Release{{Name: "v2.0.0"}, {Name: "v1.0.0"}}}
+
+	if _, err := QueryChangelog(root, Query{From: "v2.0.0", To: "v1.0.0"}); err == nil {
+		t.Error("expected an error when from is newer than to")
+	}
+
+	if _, err := QueryChangelog(root, Query{From: "v1.0.0", To: "v2.0.0"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQueryAuthors(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Fix it, thanks @piot", true},
+		{"Fix it (@Piot)", true},
+		{"Fix it, thanks @piot-bot", false},
+		{"Fix it, thanks @piotr", false},
+		{"Fix it, thanks @piot_x", false},
+		{"Mail contact@piot.dev", false},
+		{"Call `@piot`", false},
+		{`Ask \@piot`, false},
+		{"Fix **thanks @piot**", true},
+	}
+
+	authors := (&Query{Authors: []string{"@PIOT"}}).authorNames()
+	for _, test := range tests {
+		got, err := mentionsAuthor(test.text, authors, testLinkContext())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("%q: got %v, want %v", test.text, got, test.want)
+		}
+	}
+}
+
+func TestQueryAuthorsAgreeWithStatistics(t *testing.T) {
+	root, err := ReadChangelog(bytes.NewBufferString(`
+repo: piot/nimble
+releases:
+  - name: v1.0.0
+    date: 2023-06-22
+    sections:
+      main:
+        changes:
+          fixed:
+            - Fix it, thanks @piot
+            - Mail contact@piot.dev
+            - Call `+"`@piot`"+`
+            - 'Ask \@piot'
+`), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := QueryChangelog(root, Query{Authors: []string{"piot"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Releases) != 1 || len(result.Releases[0].Sections["main"].Changes.Fixed) != 1 {
+		t.Fatalf("got %+v, want only the entry with the profile link", result.Releases)
+	}
+
+	document, err := BuildDocument(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statistics := CollectStatistics(document)
+	if len(statistics.Contributors) != 1 || statistics.Contributors[0].Count != 1 {
+		t.Errorf("statistics should count the same single entry, got %+v", statistics.Contributors)
+	}
+}
@@ -0,0 +1,286 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// RenderInlines renders the inline nodes to a string using the formatter.
+func RenderInlines(inlines []InlineNode, formatter Formatter) string {
+	result := ""
+
+	for _, inline := range inlines {
+		switch node := inline.(type) {
+		case TextNode:
+			if node.Verbatim {
+				result += formatter.Verbatim(node.Text)
+			} else {
+				result += formatter.Text(node.Text)
+			}
+		case CodeNode:
+			result += formatter.Code(node.Code)
+		case LinkNode:
+			name := formatter.Text(node.Name)
+			if node.Children != nil {
+				name = RenderInlines(node.Children, formatter)
+			}
+			result += formatter.Link(name, node.URL)
+		case EmojiNode:
+			result += formatter.Emoji(node.Name)
+		case StrongNode:
+			result += formatter.Strong(RenderInlines(node.Children, formatter))
+		case EmphasisNode:
+			result += formatter.Emphasis(RenderInlines(node.Children, formatter))
+		case AdmonitionNode:
+			result += formatter.Admonition(node.Type, RenderInlines(node.Children, formatter))
+		default:
+			panic(fmt.Errorf("unknown inline node %T", inline))
+		}
+	}
+
+	return result
+}
+
+func renderEntry(entry *EntryNode, categories map[CategoryType]CategoryInfo, formatter Formatter,
+	options RenderOptions) string {
+	prefix := categoryIcon(entry.Category, lookupCategoryInfo(categories, entry.Category), options.CategoryIcons,
+		formatter)
+
+	if prefix == "" {
+		return RenderInlines(entry.Inlines, formatter)
+	}
+
+	return prefix + " " + RenderInlines(entry.Inlines, formatter)
+}
+
+func renderReleaseHeading(release *ReleaseNode, formatter Formatter, options RenderOptions) string {
+	heading := formatter.Link(formatter.Text(release.Name), release.URL)
+	if release.Codename != "" {
+		heading += " " + formatter.Emphasis(formatter.Text(release.Codename))
+	}
+
+	if release.Date != "" {
+		heading += " " + formatter.Text(fmt.Sprintf("(%v)", formatReleaseDate(release, options, time.Now())))
+	}
+
+	if release.Prerelease {
+		heading += " " + formatter.Text("[PRE-RELEASE]")
+	}
+
+	// Keep a Changelog marks yanked releases with [YANKED] after the date
+	if release.Yanked {
+		heading += " " + formatter.Text("[YANKED]")
+	}
+
+	return withEmoji(formatter, "bookmark", heading)
+}
+
+// renderReleaseMetadata returns the yanked warning and the links, that are written before the notice.
+func renderReleaseMetadata(release *ReleaseNode, formatter Formatter) string {
+	output := ""
+
+	if release.Yanked {
+		warning := "This release has been yanked."
+		if release.YankedReason != "" {
+			warning = fmt.Sprintf("This release has been yanked: %v", release.YankedReason)
+		}
+		output += formatter.Admonition(Warning, formatter.Text(warning)) + "\n\n"
+	}
+
+	if len(release.Links) > 0 {
+		links := ""
+		for index, link := range release.Links {
+			if index > 0 {
+				links += formatter.Text(" | ")
+			}
+			links += formatter.Link(formatter.Text(link.Name), link.URL)
+		}
+		output += links + "\n\n"
+	}
+
+	return output
+}
+
+func renderAssets(release *ReleaseNode, formatter Formatter) string {
+	if len(release.Assets) == 0 {
+		return ""
+	}
+
+	output := formatter.Heading(3, formatter.Text("Assets"))
+	for _, asset := range release.Assets {
+		item := formatter.Link(formatter.Text(asset.Name), asset.URL)
+		if asset.Checksum != "" {
+			item += " " + formatter.Code(asset.Checksum)
+		}
+		output += formatter.BulletPoint(item)
+	}
+
+	return output + "\n"
+}
+
+func renderGroupHeading(group *GroupNode, formatter Formatter) string {
+	if group.Kind == SectionGroup {
+		return formatter.Text(group.Key)
+	}
+
+	description := ""
+	if group.Description != "" {
+		description = formatter.Text(fmt.Sprintf(" - %v", group.Description))
+	}
+
+	return fmt.Sprintf("%s%v", formatter.Link(formatter.Text(group.Key), group.URL), description)
+}
+
+func renderGroup(group *GroupNode, categories map[CategoryType]CategoryInfo, formatter Formatter,
+	options RenderOptions, writer io.Writer) error {
+	heading := formatter.Anchor(group.ID) + formatter.Heading(3, renderGroupHeading(group, formatter))
+	if _, err := fmt.Fprint(writer, heading); err != nil {
+		return err
+	}
+
+	if len(group.Notice) > 0 {
+		if _, err := fmt.Fprintf(writer, "%v\n\n", RenderInlines(group.Notice, formatter)); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range group.Entries {
+		bulletPoint := formatter.BulletPoint(renderEntry(&entry, categories, formatter, options))
+		if _, err := fmt.Fprint(writer, bulletPoint); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(writer, "\n")
+
+	return err
+}
+
+func renderTableOfContents(releases []ReleaseNode, formatter Formatter, writer io.Writer) error {
+	for _, release := range releases {
+		releaseItem := formatter.ListItem(0, formatter.InternalLink(release.Name, release.ID))
+		if _, err := fmt.Fprint(writer, releaseItem); err != nil {
+			return err
+		}
+
+		for _, group := range release.Groups {
+			groupItem := formatter.ListItem(1, formatter.InternalLink(group.Key, group.ID))
+			if _, err := fmt.Fprint(writer, groupItem); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(writer, "\n")
+
+	return err
+}
+
+func renderContributors(release *ReleaseNode, formatter Formatter, writer io.Writer) error {
+	contributors := ReleaseContributors(release)
+	if len(contributors) == 0 {
+		return nil
+	}
+
+	links := ""
+	for index, contributor := range contributors {
+		if index > 0 {
+			links += formatter.Text(", ")
+		}
+		links += formatter.Link(formatter.Text(contributor.Name), githubUrlPrefix+contributor.Name[1:])
+	}
+
+	output := formatter.Heading(3, formatter.Text("Contributors")) +
+		withEmoji(formatter, "heart", formatter.Text("Thank you ")+links) + "\n\n"
+	_, err := fmt.Fprint(writer, output)
+
+	return err
+}
+
+func renderRelease(release *ReleaseNode, categories map[CategoryType]CategoryInfo, formatter Formatter,
+	options RenderOptions, writer io.Writer) error {
+	heading := formatter.Anchor(release.ID) + formatter.Heading(2, renderReleaseHeading(release, formatter, options)) +
+		renderReleaseMetadata(release, formatter)
+	if _, err := fmt.Fprint(writer, heading); err != nil {
+		return err
+	}
+
+	if len(release.Notice) > 0 {
+		if _, err := fmt.Fprintf(writer, "%v\n\n", RenderInlines(release.Notice, formatter)); err != nil {
+			return err
+		}
+	}
+
+	for _, group := range release.Groups {
+		if err := renderGroup(&group, categories, formatter, options, writer); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprint(writer, renderAssets(release, formatter)); err != nil {
+		return err
+	}
+
+	if options.Contributors {
+		return renderContributors(release, formatter, writer)
+	}
+
+	return nil
+}
+
+// RenderDocument writes the document tree using the formatter.
+func RenderDocument(document *DocumentNode, formatter Formatter, options RenderOptions, writer io.Writer) error {
+	if _, err := lookupDateLocale(options.DateLocale); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(writer, formatter.Heading(1, formatter.Text(document.Title))); err != nil {
+		return err
+	}
+
+	releases := document.Releases
+	if options.MaxReleases > 0 && len(releases) > options.MaxReleases {
+		releases = releases[:options.MaxReleases]
+	}
+
+	if options.TableOfContents {
+		if err := renderTableOfContents(releases, formatter, writer); err != nil {
+			return err
+		}
+	}
+
+	for index, release := range releases {
+		if options.CollapseAfter > 0 && index == options.CollapseAfter {
+			if _, err := fmt.Fprint(writer, formatter.BeginCollapsible(formatter.Text("Older releases"))); err != nil {
+				return err
+			}
+		}
+
+		if err := renderRelease(&release, document.Categories, formatter, options, writer); err != nil {
+			return err
+		}
+	}
+
+	if options.CollapseAfter > 0 && len(releases) > options.CollapseAfter {
+		if _, err := fmt.Fprint(writer, formatter.EndCollapsible()); err != nil {
+			return err
+		}
+	}
+
+	if options.ArchiveURL != "" {
+		archiveLink := formatter.Link(formatter.Text("full changelog"), options.ArchiveURL)
+		footer := fmt.Sprintf("%s%s%s\n", formatter.Text("All releases are available in the "), archiveLink,
+			formatter.Text("."))
+		if _, err := fmt.Fprint(writer, footer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
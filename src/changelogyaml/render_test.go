@@ -0,0 +1,163 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func renderTestDocument(t *testing.T, yamlText string, formatter Formatter, options RenderOptions) string {
+	t.Helper()
+
+	root, err := ReadChangelog(bytes.NewBufferString(yamlText), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	if err := WriteDocumentWithOptions(root, formatter, options, &output); err != nil {
+		t.Fatal(err)
+	}
+
+	return output.String()
+}
+
+// checkOrder checks that every part is found in the output, in order.
+func checkOrder(t *testing.T, output string, parts []string) {
+	t.Helper()
+
+	position := 0
+	for _, part := range parts {
+		index := strings.Index(output[position:], part)
+		if index < 0 {
+			t.Errorf("%q is missing, or not in order, in:\n%s", part, output)
+			return
+		}
+		position += index + len(part)
+	}
+}
+
+const releasesTestChangelog = `
+repo: piot/nimble
+releases:
+  - name: v4.0.0
+    date: 2023-06-04
+  - name: v3.0.0
+    date: 2023-06-03
+  - name: v2.0.0
+    date: 2023-06-02
+  - name: v1.0.0
+    date: 2023-06-01
+`
+
+func TestRenderReleaseOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		options   RenderOptions
+		want      []string
+		notWant   []string
+	}{
+		{"all releases", &MarkdownFormatter{}, RenderOptions{},
+			[]string{"[v4.0.0]", "[v3.0.0]", "[v2.0.0]", "[v1.0.0]"}, []string{"<details>", "full changelog"}},
+		{"max releases", &MarkdownFormatter{}, RenderOptions{MaxReleases: 2},
+			[]string{"[v4.0.0]", "[v3.0.0]"}, []string{"v2.0.0", "v1.0.0"}},
+		{"max releases above count", &MarkdownFormatter{}, RenderOptions{MaxReleases: 10},
+			[]string{"[v4.0.0]", "[v1.0.0]"}, nil},
+		{"collapse", &MarkdownFormatter{}, RenderOptions{CollapseAfter: 2},
+			[]string{"[v4.0.0]", "[v3.0.0]", "<details>\n<summary>Older releases</summary>\n\n", "[v2.0.0]", "[v1.0.0]",
+				"</details>\n"}, nil},
+		{"collapse after all releases", &MarkdownFormatter{}, RenderOptions{CollapseAfter: 4},
+			[]string{"[v1.0.0]"}, []string{"<details>"}},
+		{"collapse within max releases", &MarkdownFormatter{}, RenderOptions{MaxReleases: 2, CollapseAfter: 1},
+			[]string{"[v4.0.0]", "<details>", "[v3.0.0]", "</details>"}, []string{"v2.0.0"}},
+		{"collapse asciidoc", &AsciiDocFormatter{}, RenderOptions{CollapseAfter: 3},
+			[]string{"\n== &#x1F516; link:https://github.com/piot/nimble/releases/tag/v3.0.0[v3.0.0]",
+				".Older releases\n[%collapsible]\n====\n",
+				"[discrete]\n== &#x1F516; link:https://github.com/piot/nimble/releases/tag/v1.0.0[v1.0.0]", "====\n\n"},
+			[]string{"[discrete]\n== &#x1F516; link:https://github.com/piot/nimble/releases/tag/v3.0.0"}},
+		{"archive link", &MarkdownFormatter{}, RenderOptions{MaxReleases: 1, ArchiveURL: "https://example.com/all"},
+			[]string{"[v4.0.0]", "All releases are available in the [full changelog](https://example.com/all).\n"},
+			[]string{"v3.0.0"}},
+		{"archive link asciidoc", &AsciiDocFormatter{}, RenderOptions{ArchiveURL: "https://example.com/all"},
+			[]string{"All releases are available in the link:https://example.com/all[full changelog].\n"}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := renderTestDocument(t, releasesTestChangelog, test.formatter, test.options)
+			checkOrder(t, output, test.want)
+			for _, notWant := range test.notWant {
+				if strings.Contains(output, notWant) {
+					t.Errorf("%q should not be in:\n%s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderReleaseMetadata(t *testing.T) {
+	const yamlText = `repos:
+  main:
+    repo: piot/main
+releases:
+  - name: 0.2.0-rc.1
+    date: 2023-02-01
+    codename: Hedgehog
+    tag: v0.2.0-rc.1
+    prerelease: true
+    links:
+      - name: Docs
+        url: https://example.com/docs
+    assets:
+      - name: app.zip
+        url: https://example.com/app.zip
+        checksum: sha256:2c26b46b
+  - name: 0.1.1
+    date: 2023-01-10
+    yanked: true
+  - name: 0.1.0
+    date: 2023-01-02
+    yanked: true
+    yankedReason: Corrupts saves, use 0.1.1
+`
+
+	tests := []struct {
+		name      string
+		formatter Formatter
+		want      []string
+	}{
+		{"markdown", &MarkdownFormatter{}, []string{
+			"[0.2.0-rc.1](https://github.com//releases/tag/v0.2.0-rc.1) _Hedgehog_ (2023-02-01) \\[PRE-RELEASE\\]",
+			"[Docs](https://example.com/docs)",
+			"### Assets",
+			"* [app.zip](https://example.com/app.zip) `sha256:2c26b46b`",
+			"[0.1.1](https://github.com//releases/tag/0.1.1) (2023-01-10) \\[YANKED\\]",
+			"> This release has been yanked.",
+			"[0.1.0](https://github.com//releases/tag/0.1.0) (2023-01-02) \\[YANKED\\]",
+			"> [!WARNING]\\\n> This release has been yanked: Corrupts saves, use 0.1.1",
+		}},
+		{"asciidoc", &AsciiDocFormatter{}, []string{
+			"link:https://github.com//releases/tag/v0.2.0-rc.1[0.2.0-rc.1] __Hedgehog__ (2023-02-01) " +
+				"&#91;PRE-RELEASE&#93;",
+			"link:https://example.com/docs[Docs]",
+			"=== Assets",
+			"* link:https://example.com/app.zip[app.zip] `+sha256:2c26b46b+`",
+			"link:https://github.com//releases/tag/0.1.1[0.1.1] (2023-01-10) &#91;YANKED&#93;",
+			"WARNING: This release has been yanked.",
+			"link:https://github.com//releases/tag/0.1.0[0.1.0] (2023-01-02) &#91;YANKED&#93;",
+			"WARNING: This release has been yanked: Corrupts saves, use 0.1.1",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkOrder(t, renderTestDocument(t, yamlText, test.formatter, RenderOptions{}), test.want)
+		})
+	}
+}
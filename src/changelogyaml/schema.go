@@ -0,0 +1,20 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	_ "embed"
+)
+
+//go:generate go run ./internal/schemagen -o changelog.schema.json types.go entry.go autolink_rule.go
+
+//go:embed changelog.schema.json
+var jsonSchema []byte
+
+// JSONSchema returns the JSON Schema for the changelog YAML format. It is generated from the types with go generate.
+func JSONSchema() []byte {
+	return jsonSchema
+}
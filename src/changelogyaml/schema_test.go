@@ -0,0 +1,51 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchemaIsValidJSON(t *testing.T) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(JSONSchema(), &root); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"$schema", "$ref", "$defs"} {
+		if _, found := root[key]; !found {
+			t.Errorf("schema is missing %q", key)
+		}
+	}
+}
+
+func TestSchemaMatchesGenerated(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	output := filepath.Join(t.TempDir(), "changelog.schema.json")
+	command := exec.Command(goTool, "run", "./internal/schemagen", "-o", output,
+		"types.go", "entry.go", "autolink_rule.go")
+	if combined, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("schemagen failed: %v\n%s", err, combined)
+	}
+
+	generated, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(generated, JSONSchema()) {
+		t.Error("changelog.schema.json is out of date, run go generate")
+	}
+}
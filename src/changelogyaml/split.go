@@ -0,0 +1,248 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// FrontMatter is the data that is available in a front matter template.
+type FrontMatter struct {
+	Title string
+	// Date is empty for releases without a date, e.g. `Unreleased`.
+	Date    string
+	Version string
+	ID      string
+	// Tags are the names of the categories that are present in the release.
+	Tags []string
+}
+
+var frontMatterPresets = map[string]string{
+	"none": "",
+	"hugo": `---
+title: {{ quote .Title }}
+{{- if .Date }}
+date: {{ quote .Date }}
+{{- end }}
+version: {{ quote .Version }}
+tags: {{ list .Tags }}
+---
+
+`,
+	"jekyll": `---
+layout: page
+title: {{ quote .Title }}
+{{- if .Date }}
+date: {{ quote .Date }}
+{{- end }}
+version: {{ quote .Version }}
+tags: {{ list .Tags }}
+---
+
+`,
+	"docusaurus": `---
+id: {{ .ID }}
+title: {{ quote .Title }}
+sidebar_label: {{ quote .Version }}
+tags: {{ list .Tags }}
+---
+
+`,
+	"mkdocs": `---
+title: {{ quote .Title }}
+{{- if .Date }}
+date: {{ quote .Date }}
+{{- end }}
+tags: {{ list .Tags }}
+---
+
+`,
+}
+
+// FrontMatterPreset returns the front matter template for a documentation site generator:
+// none, hugo, jekyll, docusaurus or mkdocs.
+func FrontMatterPreset(name string) (string, error) {
+	preset, found := frontMatterPresets[name]
+	if !found {
+		return "", fmt.Errorf("unknown front matter preset '%s'", name)
+	}
+
+	return preset, nil
+}
+
+// SplitOptions controls how WriteReleaseFiles writes the files.
+type SplitOptions struct {
+	// Extension of all written files, including the dot, e.g. ".md".
+	Extension string
+
+	// FrontMatterTemplate is a text/template, executed with FrontMatter, that is written at the start of every
+	// release file. See FrontMatterPreset.
+	FrontMatterTemplate string
+
+	// IndexName is the filename of the index file, without extension. Defaults to "index".
+	IndexName string
+
+	// RenderOptions is used for every release file, and MaxReleases also limits the files that are written.
+	// TableOfContents, CollapseAfter and ArchiveURL only apply to a whole document and are ignored. Front matter
+	// always has the date as it is in the YAML file.
+	RenderOptions
+}
+
+var unsafeFilenameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ReleaseFilename returns the filename, without extension, that is used for a release, e.g. `v1.2.0`.
+func ReleaseFilename(release *ReleaseNode) string {
+	return strings.Trim(unsafeFilenameCharacters.ReplaceAllString(release.Name, "-"), "-.")
+}
+
+func releaseTags(release *ReleaseNode, categories map[CategoryType]CategoryInfo) []string {
+	var tags []string
+	found := make(map[CategoryType]bool)
+
+	for _, group := range release.Groups {
+		for _, entry := range group.Entries {
+			if !found[entry.Category] {
+				found[entry.Category] = true
+				tags = append(tags, lookupCategoryInfo(categories, entry.Category).Name)
+			}
+		}
+	}
+
+	return tags
+}
+
+func frontMatterFuncs() template.FuncMap {
+	return template.FuncMap{
+		"quote": strconv.Quote,
+		"list": func(values []string) string {
+			quoted := make([]string, 0, len(values))
+			for _, value := range values {
+				quoted = append(quoted, strconv.Quote(value))
+			}
+			return "[" + strings.Join(quoted, ", ") + "]"
+		},
+	}
+}
+
+func writeReleaseFile(release *ReleaseNode, categories map[CategoryType]CategoryInfo,
+	frontMatter *template.Template, formatter Formatter, options RenderOptions, filename string) error {
+	var output bytes.Buffer
+
+	if frontMatter != nil {
+		data := FrontMatter{
+			Title:   release.Name,
+			Date:    release.Date,
+			Version: release.Name,
+			ID:      release.ID,
+			Tags:    releaseTags(release, categories),
+		}
+
+		if err := frontMatter.Execute(&output, data); err != nil {
+			return err
+		}
+	}
+
+	if err := renderRelease(release, categories, formatter, options, &output); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, output.Bytes(), 0o644)
+}
+
+// releaseFilenames returns the filename, without extension, for each release. It is an error if two releases, or a
+// release and the index file, would be written to the same file.
+func releaseFilenames(releases []ReleaseNode, indexName string) ([]string, error) {
+	filenames := make([]string, 0, len(releases))
+	// Filenames are compared without case, since many file systems are case-insensitive
+	names := map[string]string{strings.ToLower(indexName): ""}
+
+	for index := range releases {
+		release := &releases[index]
+		filename := ReleaseFilename(release)
+		if filename == "" {
+			return nil, fmt.Errorf("release '%s' has no characters that can be used in a filename", release.Name)
+		}
+
+		if otherName, found := names[strings.ToLower(filename)]; found {
+			if otherName == "" {
+				return nil, fmt.Errorf("release '%s' would overwrite the index file '%s'", release.Name, indexName)
+			}
+			return nil, fmt.Errorf("releases '%s' and '%s' would both be written to '%s'", otherName, release.Name,
+				filename)
+		}
+
+		names[strings.ToLower(filename)] = release.Name
+		filenames = append(filenames, filename)
+	}
+
+	return filenames, nil
+}
+
+// WriteReleaseFiles writes one file per release to the directory, and an index file that links to all of them.
+func WriteReleaseFiles(document *DocumentNode, formatter Formatter, options SplitOptions, directory string) error {
+	if _, err := lookupDateLocale(options.DateLocale); err != nil {
+		return err
+	}
+
+	indexName := options.IndexName
+	if indexName == "" {
+		indexName = "index"
+	}
+
+	releases := document.Releases
+	if options.MaxReleases > 0 && len(releases) > options.MaxReleases {
+		releases = releases[:options.MaxReleases]
+	}
+
+	filenames, err := releaseFilenames(releases, indexName)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return err
+	}
+
+	var frontMatter *template.Template
+	if options.FrontMatterTemplate != "" {
+		frontMatter, err = template.New("frontMatter").Funcs(frontMatterFuncs()).Parse(options.FrontMatterTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	var index bytes.Buffer
+	index.WriteString(formatter.Heading(1, formatter.Text(document.Title)))
+
+	for releaseIndex, release := range releases {
+		releaseFilename := filenames[releaseIndex] + options.Extension
+		if err := writeReleaseFile(&release, document.Categories, frontMatter, formatter, options.RenderOptions,
+			filepath.Join(directory, releaseFilename)); err != nil {
+			return err
+		}
+
+		releaseLink := formatter.Link(formatter.Text(release.Name), releaseFilename)
+		item := releaseLink
+		if release.Date != "" {
+			date := formatReleaseDate(&release, options.RenderOptions, time.Now())
+			item += " " + formatter.Text(fmt.Sprintf("(%v)", date))
+		}
+		if release.Yanked {
+			item += " " + formatter.Text("[YANKED]")
+		}
+		index.WriteString(formatter.BulletPoint(item))
+	}
+
+	return os.WriteFile(filepath.Join(directory, indexName+options.Extension), index.Bytes(), 0o644)
+}
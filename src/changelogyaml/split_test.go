@@ -0,0 +1,98 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func buildTestDocument(t *testing.T, yamlText string) *DocumentNode {
+	t.Helper()
+
+	root, err := ReadChangelog(bytes.NewBufferString(yamlText), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document, err := BuildDocument(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return document
+}
+
+func TestFrontMatterWithoutDate(t *testing.T) {
+	document := buildTestDocument(t, `
+repo: piot/nimble
+releases:
+  - name: Unreleased
+  - name: v1.0.0
+    date: 2023-06-22
+`)
+
+	preset, err := FrontMatterPreset("hugo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directory := t.TempDir()
+	options := SplitOptions{Extension: ".md", FrontMatterTemplate: preset}
+	if err := WriteReleaseFiles(document, &MarkdownFormatter{}, options, directory); err != nil {
+		t.Fatal(err)
+	}
+
+	unreleased, err := os.ReadFile(filepath.Join(directory, "Unreleased.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(unreleased), "---\ntitle: \"Unreleased\"\nversion: \"Unreleased\"\n") {
+		t.Errorf("unreleased should have no date:\n%s", unreleased)
+	}
+
+	released, err := os.ReadFile(filepath.Join(directory, "v1.0.0.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(released), "---\ntitle: \"v1.0.0\"\ndate: \"2023-06-22\"\nversion: \"v1.0.0\"\n") {
+		t.Errorf("release should have the date:\n%s", released)
+	}
+}
+
+func TestReleaseFilenameCollisions(t *testing.T) {
+	tests := []struct {
+		name     string
+		releases []string
+		want     string
+	}{
+		{"same filename", []string{"v1 0", "v1/0"}, "would both be written to 'v1-0'"},
+		{"only case differs", []string{"Beta", "beta"}, "would both be written to 'beta'"},
+		{"index file", []string{"Index"}, "would overwrite the index file"},
+		{"no filename", []string{"???"}, "no characters that can be used in a filename"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var releases []ReleaseNode
+			for _, name := range test.releases {
+				releases = append(releases, ReleaseNode{Name: name})
+			}
+
+			_, err := releaseFilenames(releases, "index")
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got %v, want an error with %q", err, test.want)
+			}
+		})
+	}
+
+	if _, err := releaseFilenames([]ReleaseNode{{Name: "v1.0.0"}, {Name: "v1.0.1"}}, "index"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,178 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"sort"
+	"strings"
+)
+
+// Count is a name with the number of entries.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type ReleaseStatistics struct {
+	Name         string  `json:"name"`
+	Date         string  `json:"date"`
+	Entries      int     `json:"entries"`
+	Categories   []Count `json:"categories"`
+	Repos        []Count `json:"repos"`
+	Contributors []Count `json:"contributors"`
+}
+
+// Statistics has the number of entries per category, repo (or section) and contributor. Categories have the same name
+// as in the output, including overrides in `categories:`. Contributors are the `@username` mentions, and are counted
+// once per entry or notice.
+type Statistics struct {
+	Entries      int                 `json:"entries"`
+	Categories   []Count             `json:"categories"`
+	Repos        []Count             `json:"repos"`
+	Contributors []Count             `json:"contributors"`
+	Releases     []ReleaseStatistics `json:"releases"`
+}
+
+// sortedCounts returns the counts with the highest count first, and names in alphabetical order when equal.
+func sortedCounts(counts map[string]int) []Count {
+	result := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, Count{Name: name, Count: count})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// mentionedProfiles appends all usernames, with `@`, that the profile autolinker found in the inlines, in the order
+// they are written.
+func mentionedProfiles(inlines []InlineNode, found []string) []string {
+	for _, inline := range inlines {
+		switch node := inline.(type) {
+		case LinkNode:
+			if node.Kind == ProfileLink {
+				found = append(found, node.Name)
+			}
+		case StrongNode:
+			found = mentionedProfiles(node.Children, found)
+		case EmphasisNode:
+			found = mentionedProfiles(node.Children, found)
+		case AdmonitionNode:
+			found = mentionedProfiles(node.Children, found)
+		}
+	}
+
+	return found
+}
+
+// contributorCounts counts the entries per user. GitHub usernames are case-insensitive, so `@Piot` and `@piot` are
+// the same user, shown as it was written the first time.
+type contributorCounts struct {
+	counts map[string]int
+	names  map[string]string
+}
+
+func newContributorCounts() *contributorCounts {
+	return &contributorCounts{counts: make(map[string]int), names: make(map[string]string)}
+}
+
+func (c *contributorCounts) add(name string) {
+	key := strings.ToLower(name)
+	if _, found := c.names[key]; !found {
+		c.names[key] = name
+	}
+	c.counts[key]++
+}
+
+func (c *contributorCounts) sorted() []Count {
+	counts := make(map[string]int, len(c.counts))
+	for key, count := range c.counts {
+		counts[c.names[key]] = count
+	}
+
+	return sortedCounts(counts)
+}
+
+// addContributors counts every user that is mentioned in the inlines once.
+func addContributors(inlines []InlineNode, counts ...*contributorCounts) {
+	seen := make(map[string]bool)
+
+	for _, name := range mentionedProfiles(inlines, nil) {
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		for _, count := range counts {
+			count.add(name)
+		}
+	}
+}
+
+// ReleaseContributors returns all users mentioned in the release, sorted by the number of entries.
+func ReleaseContributors(release *ReleaseNode) []Count {
+	contributors := newContributorCounts()
+
+	addContributors(release.Notice, contributors)
+	for _, group := range release.Groups {
+		addContributors(group.Notice, contributors)
+		for _, entry := range group.Entries {
+			addContributors(entry.Inlines, contributors)
+		}
+	}
+
+	return contributors.sorted()
+}
+
+func CollectStatistics(document *DocumentNode) Statistics {
+	var statistics Statistics
+
+	categories := make(map[string]int)
+	repos := make(map[string]int)
+	contributors := newContributorCounts()
+
+	for _, release := range document.Releases {
+		releaseCategories := make(map[string]int)
+		releaseRepos := make(map[string]int)
+		releaseContributors := newContributorCounts()
+		releaseStatistics := ReleaseStatistics{Name: release.Name, Date: release.Date}
+
+		addContributors(release.Notice, contributors, releaseContributors)
+
+		for _, group := range release.Groups {
+			addContributors(group.Notice, contributors, releaseContributors)
+
+			for _, entry := range group.Entries {
+				categoryName := lookupCategoryInfo(document.Categories, entry.Category).Name
+				categories[categoryName]++
+				releaseCategories[categoryName]++
+				repos[group.Key]++
+				releaseRepos[group.Key]++
+				addContributors(entry.Inlines, contributors, releaseContributors)
+				releaseStatistics.Entries++
+			}
+		}
+
+		releaseStatistics.Categories = sortedCounts(releaseCategories)
+		releaseStatistics.Repos = sortedCounts(releaseRepos)
+		releaseStatistics.Contributors = releaseContributors.sorted()
+		statistics.Releases = append(statistics.Releases, releaseStatistics)
+		statistics.Entries += releaseStatistics.Entries
+	}
+
+	statistics.Categories = sortedCounts(categories)
+	statistics.Repos = sortedCounts(repos)
+	statistics.Contributors = contributors.sorted()
+
+	return statistics
+}
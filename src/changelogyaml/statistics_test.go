@@ -0,0 +1,103 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContributorsAreCaseInsensitive(t *testing.T) {
+	root, err := ReadChangelog(bytes.NewBufferString(`
+repo: piot/nimble
+releases:
+  - name: v1.1.0
+    date: 2023-06-22
+    sections:
+      main:
+        changes:
+          added:
+            - Add a feature by @Piot
+            - Add another feature by @piot and @PIOT
+            - Add docs by @alice
+  - name: v1.0.0
+    date: 2023-06-01
+    sections:
+      main:
+        changes:
+          fixed:
+            - Fix a crash by @piot
+`), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document, err := BuildDocument(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statistics := CollectStatistics(document)
+
+	want := []Count{{Name: "@Piot", Count: 3}, {Name: "@alice", Count: 1}}
+	if !reflect.DeepEqual(statistics.Contributors, want) {
+		t.Errorf("got %v, want %v", statistics.Contributors, want)
+	}
+
+	wantRelease := []Count{{Name: "@Piot", Count: 2}, {Name: "@alice", Count: 1}}
+	if got := ReleaseContributors(&document.Releases[0]); !reflect.DeepEqual(got, wantRelease) {
+		t.Errorf("got %v, want %v", got, wantRelease)
+	}
+}
+
+func TestStatisticsCategoryNames(t *testing.T) {
+	root, err := ReadChangelog(bytes.NewBufferString(`
+repo: piot/nimble
+categories:
+  fixed:
+    name: bug fix
+releases:
+  - name: v1.0.0
+    date: 2023-06-22
+    sections:
+      main:
+        changes:
+          fixed:
+            - Fix a crash
+            - Fix a leak
+          noted:
+            - Crashes on exit
+`), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document, err := BuildDocument(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statistics := CollectStatistics(document)
+
+	want := []Count{{Name: "bug fix", Count: 2}, {Name: "known issue", Count: 1}}
+	if !reflect.DeepEqual(statistics.Categories, want) {
+		t.Errorf("got %v, want %v", statistics.Categories, want)
+	}
+
+	var output bytes.Buffer
+	if err := RenderDocument(document, &MarkdownFormatter{}, RenderOptions{CategoryIcons: LabelIcons},
+		&output); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, count := range want {
+		if label := `\[` + count.Name + `\]`; !strings.Contains(output.String(), label) {
+			t.Errorf("the rendered output has no label %q:\n%s", label, output.String())
+		}
+	}
+}
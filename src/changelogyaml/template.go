@@ -0,0 +1,218 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateEntry is a single changelog line.
+type TemplateEntry struct {
+	// Raw is the text exactly as written in the YAML file.
+	Raw string
+	// Text has the pull request, commit hash and profile links resolved by the formatter.
+	Text string
+}
+
+// TemplateCategory holds all entries of one category (fixed, added, ...) within a group.
+type TemplateCategory struct {
+	Type       CategoryType
+	Name       string
+	EmojiName  string
+	BadgeColor string
+	Entries    []TemplateEntry
+}
+
+// TemplateGroup is either a section or a repo within a release.
+type TemplateGroup struct {
+	// ID is the stable anchor ID.
+	ID string
+	// Key is the section name or the repo key used in the YAML file.
+	Key string
+	// Name is the display name. For repos it is the name from the repo definition, if set.
+	Name        string
+	Repo        string
+	URL         string
+	Description string
+	RawNotice   string
+	// Notice has admonitions and profile links resolved by the formatter.
+	Notice     string
+	Categories []TemplateCategory
+}
+
+type TemplateRelease struct {
+	// ID is the stable anchor ID, e.g. `v0-0-1-a06`.
+	ID       string
+	Name     string
+	Codename string
+	Date     string
+	// Time is the parsed Date, zero for an unreleased release without a date. See the formatDate helper.
+	Time         time.Time
+	URL          string
+	Prerelease   bool
+	Yanked       bool
+	YankedReason string
+	Links        []ReleaseLink
+	Assets       []ReleaseAsset
+	RawNotice    string
+	// Notice has admonitions and profile links resolved by the formatter.
+	Notice   string
+	Sections []TemplateGroup
+	Repos    []TemplateGroup
+}
+
+// TemplateDocument is the view model that is passed as the root object (`.`) to a template.
+type TemplateDocument struct {
+	Repo     string
+	URL      string
+	Releases []TemplateRelease
+}
+
+func templateCategories(entries []EntryNode, categories map[CategoryType]CategoryInfo,
+	formatter Formatter) []TemplateCategory {
+	var templateCategories []TemplateCategory
+
+	for _, entry := range entries {
+		if len(templateCategories) == 0 || templateCategories[len(templateCategories)-1].Type != entry.Category {
+			categoryInfo := lookupCategoryInfo(categories, entry.Category)
+			templateCategories = append(templateCategories, TemplateCategory{
+				Type:       entry.Category,
+				Name:       categoryInfo.Name,
+				EmojiName:  categoryInfo.EmojiName,
+				BadgeColor: categoryInfo.BadgeColor,
+			})
+		}
+
+		category := &templateCategories[len(templateCategories)-1]
+		category.Entries = append(category.Entries, TemplateEntry{
+			Raw:  entry.Raw,
+			Text: RenderInlines(entry.Inlines, formatter),
+		})
+	}
+
+	return templateCategories
+}
+
+func templateGroup(group *GroupNode, rawNotice string, categories map[CategoryType]CategoryInfo,
+	formatter Formatter) TemplateGroup {
+	return TemplateGroup{
+		ID:          group.ID,
+		Key:         group.Key,
+		Name:        group.Name,
+		Repo:        group.Repo,
+		URL:         group.URL,
+		Description: group.Description,
+		RawNotice:   rawNotice,
+		Notice:      RenderInlines(group.Notice, formatter),
+		Categories:  templateCategories(group.Entries, categories, formatter),
+	}
+}
+
+// NewTemplateDocument builds the template view model from a parsed changelog.
+// Sections are ordered by their Order field and repos by their key, the same order that WriteDocument uses.
+func NewTemplateDocument(root *ChangelogYaml, formatter Formatter) (*TemplateDocument, error) {
+	documentNode, err := BuildDocument(root)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &TemplateDocument{
+		Repo: documentNode.Repo,
+		URL:  documentNode.URL,
+	}
+
+	for releaseIndex, releaseNode := range documentNode.Releases {
+		release := &root.Releases[releaseIndex]
+		templateRelease := TemplateRelease{
+			ID:           releaseNode.ID,
+			Name:         releaseNode.Name,
+			Codename:     releaseNode.Codename,
+			Date:         releaseNode.Date,
+			Time:         releaseNode.Time,
+			URL:          releaseNode.URL,
+			Prerelease:   releaseNode.Prerelease,
+			Yanked:       releaseNode.Yanked,
+			YankedReason: releaseNode.YankedReason,
+			Links:        releaseNode.Links,
+			Assets:       releaseNode.Assets,
+			RawNotice:    release.Notice,
+			Notice:       RenderInlines(releaseNode.Notice, formatter),
+		}
+
+		for _, group := range releaseNode.Groups {
+			if group.Kind == SectionGroup {
+				templateRelease.Sections = append(templateRelease.Sections,
+					templateGroup(&group, release.Sections[group.Key].Notice, documentNode.Categories, formatter))
+			} else {
+				templateRelease.Repos = append(templateRelease.Repos,
+					templateGroup(&group, "", documentNode.Categories, formatter))
+			}
+		}
+
+		document.Releases = append(document.Releases, templateRelease)
+	}
+
+	return document, nil
+}
+
+// TemplateFuncs returns the helper functions available in templates. The output of each helper is
+// produced by the given formatter, so the same template can be used for Markdown and AsciiDoc.
+func TemplateFuncs(formatter Formatter) template.FuncMap {
+	return template.FuncMap{
+		"text":      formatter.Text,
+		"code":      formatter.Code,
+		"codeBlock": formatter.CodeBlock,
+		"emoji":     formatter.Emoji,
+		"image":     formatter.Image,
+		"link": func(name string, url string) string {
+			return formatter.Link(formatter.Text(name), url)
+		},
+		"heading": func(level int, text string) string {
+			return formatter.Heading(level, text)
+		},
+		"bullet":           formatter.BulletPoint,
+		"anchor":           formatter.Anchor,
+		"internalLink":     formatter.InternalLink,
+		"beginCollapsible": formatter.BeginCollapsible,
+		"endCollapsible":   formatter.EndCollapsible,
+		"admonition": func(name string, text string) string {
+			return formatter.Admonition(stringToAdmonitionType(strings.ToUpper(name)), text)
+		},
+		"notice": func(text string) string {
+			return RenderInlines(convertNotice(text), formatter)
+		},
+		// formatDate takes a date format and a locale, as in RenderOptions, e.g. `formatDate .Time "long" "de"`
+		"formatDate": func(date time.Time, format string, locale string) (string, error) {
+			if _, err := lookupDateLocale(locale); err != nil {
+				return "", err
+			}
+			if date.IsZero() {
+				return "", nil
+			}
+			release := ReleaseNode{Date: date.Format("2006-01-02"), Time: date}
+			return formatReleaseDate(&release, RenderOptions{DateFormat: format, DateLocale: locale}, time.Now()), nil
+		},
+	}
+}
+
+// WriteTemplateDocument renders the changelog using a text/template instead of the fixed structure of WriteDocument.
+func WriteTemplateDocument(root *ChangelogYaml, templateText string, outputFormatter Formatter,
+	writer io.Writer) error {
+	tmpl, err := template.New("changelog").Funcs(TemplateFuncs(outputFormatter)).Parse(templateText)
+	if err != nil {
+		return err
+	}
+
+	document, err := NewTemplateDocument(root, outputFormatter)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(writer, document)
+}
@@ -0,0 +1,123 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func renderTestTemplate(t *testing.T, yamlText string, templateText string, formatter Formatter) string {
+	t.Helper()
+
+	root, err := ReadChangelog(bytes.NewBufferString(yamlText), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var output bytes.Buffer
+	if err := WriteTemplateDocument(root, templateText, formatter, &output); err != nil {
+		t.Fatal(err)
+	}
+
+	return output.String()
+}
+
+func TestTemplateLinkEscapesName(t *testing.T) {
+	got := renderTestTemplate(t, "releases: []\n", `{{ link "a_b" "https://example.com" }}`, &MarkdownFormatter{})
+
+	if want := `[a\_b](https://example.com)`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+const templateTestChangelog = `
+repo: piot/nimble
+repos:
+  clog:
+    repo: piot/clog
+releases:
+  - name: v1.0.0
+    date: 2023-06-22
+    notice: "NOTE: Thanks @piot"
+    sections:
+      main:
+        notice: Main notice
+        changes:
+          fixed:
+            - "Fix #12"
+            - Fix the <crash>
+          added:
+            - Add a_b
+    repos:
+      clog:
+        changed:
+          - Change it
+`
+
+func TestTemplateViewModel(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"document", "{{ .Repo }} {{ .URL }}", "piot/nimble https://github.com/piot/nimble"},
+		{"release", "{{ range .Releases }}{{ .ID }} {{ .Name }} {{ .Date }} {{ .Time.Year }}{{ end }}",
+			"v1-0-0 v1.0.0 2023-06-22 2023"},
+		{"release notice", "{{ range .Releases }}{{ .RawNotice }}|{{ .Notice }}{{ end }}",
+			"NOTE: Thanks @piot|> [!NOTE]\\\n> Thanks [@piot](https://github.com/piot)"},
+		{"section", "{{ range .Releases }}{{ range .Sections }}{{ .Key }} {{ .RawNotice }}{{ end }}{{ end }}",
+			"main Main notice"},
+		{"repo", "{{ range .Releases }}{{ range .Repos }}{{ .Key }} {{ .Repo }}{{ end }}{{ end }}", "clog piot/clog"},
+		{"format date", `{{ range .Releases }}{{ formatDate .Time "long" "de" }}{{ end }}`, "22. Juni 2023"},
+		{"categories", "{{ range .Releases }}{{ range .Sections }}{{ range .Categories }}" +
+			"{{ .Name }}:{{ .EmojiName }}:{{ len .Entries }} {{ end }}{{ end }}{{ end }}",
+			"added:star2:1 fixed:lady_beetle:2 "},
+		{"entries", "{{ range .Releases }}{{ range .Sections }}{{ range .Categories }}{{ range .Entries }}" +
+			"{{ .Raw }}|{{ .Text }}\n{{ end }}{{ end }}{{ end }}{{ end }}",
+			"Add a_b|Add a\\_b\nFix #12|Fix [\\#12](https://github.com/piot/nimble/pull/12)\n" +
+				"Fix the <crash>|Fix the \\<crash\\>\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := renderTestTemplate(t, templateTestChangelog, test.template, &MarkdownFormatter{})
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		template string
+		markdown string
+		asciiDoc string
+	}{
+		{`{{ text "a_b" }}`, `a\_b`, "a&#95;b"},
+		{`{{ code "a_b" }}`, "`a_b`", "`+a_b+`"},
+		{`{{ emoji "bookmark" }}`, ":bookmark:", "&#x1F516;"},
+		{`{{ heading 2 "Title" }}`, "## Title\n\n", "== Title\n\n"},
+		{`{{ bullet "item" }}`, "* item\n", "* item\n"},
+		{`{{ anchor "v1" }}`, "<a id=\"v1\"></a>\n\n", "[[v1]]\n"},
+		{`{{ internalLink "v1.0" "v1" }}`, "[v1.0](#v1)", "<<v1,v1.0>>"},
+		{`{{ image "ci" "https://example.com/ci.svg" }}`, "![ci](https://example.com/ci.svg)",
+			"image:https://example.com/ci.svg[ci]"},
+		{`{{ admonition "note" "Read it" }}`, "> [!NOTE]\\\n> Read it", "NOTE: Read it"},
+		{`{{ notice "Thanks @piot" }}`, "Thanks [@piot](https://github.com/piot)",
+			"Thanks link:https://github.com/piot[@piot]"},
+	}
+
+	for _, test := range tests {
+		if got := renderTestTemplate(t, "releases: []\n", test.template, &MarkdownFormatter{}); got != test.markdown {
+			t.Errorf("%s: got %q, want %q", test.template, got, test.markdown)
+		}
+		if got := renderTestTemplate(t, "releases: []\n", test.template, &AsciiDocFormatter{}); got != test.asciiDoc {
+			t.Errorf("%s adoc: got %q, want %q", test.template, got, test.asciiDoc)
+		}
+	}
+}
@@ -8,69 +8,92 @@ package changelogyaml
 import (
 	"io"
 	"log"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 const githubUrlPrefix = "https://github.com/"
 
+// TagName returns the git tag of the release, which is the name unless Tag is set.
+func (r *Release) TagName() string {
+	if r.Tag != "" {
+		return r.Tag
+	}
+
+	return r.Name
+}
+
+// IsUnreleased returns true for the release that collects the changes that are not released yet. It is the only
+// release that may be without a date.
+func (r *Release) IsUnreleased() bool {
+	return strings.EqualFold(r.Name, "unreleased")
+}
+
+const (
+	// PullRequestReferences is the default, `#123` links to pull request 123.
+	PullRequestReferences = "pulls"
+	// IssueReferences makes `#123` link to issue 123.
+	IssueReferences = "issues"
+)
+
 type Changes struct {
 	// Keep a changelog https://keepachangelog.com/en/1.1.0/
 
 	// Added denotes new features or functionalities introduced in the software.
-	Added []string
+	Added []Entry
 
 	// Changed indicates changes to existing features or functionalities.
-	Changed []string
+	Changed []Entry
 
 	// Deprecated signifies functionalities that are no longer recommended and will be removed in future versions.
-	Deprecated []string
+	Deprecated []Entry
 
 	// Removed lists functionalities or features that have been removed from the software. Should have been set as Deprecated in version prior to being removed. Is implicitly breaking changes.
-	Removed []string
+	Removed []Entry
 
 	// Fixed enumerates fixes for bugs or issues in the software.
-	Fixed []string
+	Fixed []Entry
 
 	// Security includes changes related to security enhancements or fixes.
-	Security []string
+	Security []Entry
 
 	// ---------------- Others ---------------
 
 	// Improved lists improvements made to existing functionalities without adding new features.
-	Improved []string
+	Improved []Entry
 
 	// Workaround provides workarounds or temporary solutions for known issues or limitations.
-	Workaround []string
+	Workaround []Entry
 
 	// Tests includes changes or additions to testing procedures or test cases.
-	Tests []string
+	Tests []Entry
 
 	// Docs lists changes or additions to documentation, such as README files or inline code comments.
-	Docs []string
+	Docs []Entry
 
 	// Refactored denotes changes made to improve code structure or organization without changing external behavior.
-	Refactored []string
+	Refactored []Entry
 
 	// Performance includes changes aimed at improving the performance of the software.
-	Performance []string
+	Performance []Entry
 
 	// Breaking denotes changes that may break backward compatibility with previous versions. Changed, but breaks the API compatibilty.
-	Breaking []string
+	Breaking []Entry
 
 	// Experimental lists experimental features or functionalities that are not yet stable or fully supported and might be removed with short or no notice in future versions.
-	Experimental []string
+	Experimental []Entry
 
 	// Noted provides a place to note any other significant changes not covered by the above categories.
-	Noted []string
+	Noted []Entry
 
 	// Style denotes changes related to coding style, formatting, or other stylistic aspects.
-	Style []string
+	Style []Entry
 
 	// Unreleased contains a list of changes that are planned but not yet released in any version.
 	// These changes typically represent work that is in progress or pending release in a future version.
 	// Once a version is released, the changes listed in Unreleased are moved to the appropriate category (e.g., Added, Changed, Fixed, etc.).
-	Unreleased []string
+	Unreleased []Entry
 }
 
 type Section struct {
@@ -79,24 +102,96 @@ type Section struct {
 	Changes Changes
 }
 
+// ReleaseLink is a link related to a release, e.g. to the documentation or a migration guide.
+type ReleaseLink struct {
+	Name string
+	URL  string `yaml:"url"`
+}
+
+// ReleaseAsset is a download of a release.
+type ReleaseAsset struct {
+	Name string
+	URL  string `yaml:"url"`
+	// Checksum is optional, written with the algorithm first, e.g. `sha256:2c26b46b...`.
+	Checksum string `yaml:"checksum,omitempty"`
+}
+
 type Release struct {
-	Name     string
+	Name string
+	// Date is ISO-8601, e.g. `2023-06-22` or `2023-06-22T14:30:00+02:00`. It can only be left out for `Unreleased`.
 	Date     string
 	Notice   string
 	Repos    map[string]Changes `yaml:"repos"`
 	Sections map[string]Section `yaml:"sections"`
+
+	// Codename is an optional name of the release, e.g. `Hedgehog`.
+	Codename string `yaml:"codename,omitempty"`
+
+	// Tag is the git tag of the release, if it is not the same as Name.
+	Tag string `yaml:"tag,omitempty"`
+
+	// Prerelease marks releases that are not ready for production, e.g. alphas and release candidates.
+	Prerelease bool `yaml:"prerelease,omitempty"`
+
+	// Yanked marks releases that were pulled because of a serious bug or security issue, see YankedReason.
+	Yanked bool `yaml:"yanked,omitempty"`
+
+	// YankedReason tells why the release was yanked and what to use instead.
+	YankedReason string `yaml:"yankedReason,omitempty"`
+
+	// Links are related pages, e.g. the documentation or a migration guide.
+	Links []ReleaseLink `yaml:"links,omitempty"`
+
+	// Assets are the downloads of the release.
+	Assets []ReleaseAsset `yaml:"assets,omitempty"`
+
+	// Versions maps a repo key to the release name in the changelog of that repo, see RepoDefinition.Changelog.
+	Versions map[string]string `yaml:"versions,omitempty"`
 }
 
 type RepoDefinition struct {
 	Repo        string
 	Name        string
 	Description string
+
+	// References tells if `#123` in the changes of the repo refers to a pull request ("pulls", the default) or to an
+	// issue ("issues").
+	References string `yaml:"references,omitempty"`
+
+	// Changelog is an optional path to the changelog.yaml of the repo, relative to this file. The changes of each
+	// release are taken from the release named in Release.Versions, or if not mapped, from all releases with a date
+	// after the previous release up to and including the date of the release.
+	Changelog string `yaml:"changelog,omitempty"`
+}
+
+// CategoryOverride changes how a category is shown. Fields that are not set keep the default.
+type CategoryOverride struct {
+	// Emoji is the GitHub shortcode name, without colons, e.g. `bug`.
+	Emoji string `yaml:"emoji,omitempty"`
+	// Name is used in text labels and badges, e.g. `bug fix`.
+	Name string `yaml:"name,omitempty"`
+	// BadgeColor is a Shields color name, e.g. `red`, or a hex color without `#`.
+	BadgeColor string `yaml:"badgeColor,omitempty"`
 }
 
 type ChangelogYaml struct {
 	Repo     string
 	Releases []Release
 	Repos    map[string]RepoDefinition `yaml:"repos"`
+
+	// References tells if `#123` in the sections refers to a pull request ("pulls", the default) or to an issue
+	// ("issues").
+	References string `yaml:"references,omitempty"`
+
+	// Categories overrides how categories are shown. The key is the category key, e.g. `fixed`.
+	Categories map[string]CategoryOverride `yaml:"categories,omitempty"`
+
+	// Autolinks are extra rules that turn text into links, e.g. issue tracker keys.
+	Autolinks []AutolinkRule `yaml:"autolinks,omitempty"`
+
+	// Include lists other changelog files, relative to this file. Their releases are added after the releases in
+	// this file, and their repo definitions are added if not already defined.
+	Include []string `yaml:"include,omitempty"`
 }
 
 func (c *ChangelogYaml) ReadYaml(filename io.Reader) *ChangelogYaml {
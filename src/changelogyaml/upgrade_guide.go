@@ -0,0 +1,144 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"io"
+)
+
+func isUpgradeEntry(entry *EntryNode) bool {
+	return entry.Category == Breaking || entry.Category == Removed || entry.Migration != nil
+}
+
+// BuildUpgradeGuide returns the document tree with all breaking and removed entries, and every entry with a migration,
+// for the releases after `from` up to and including `to`. The releases are ordered from oldest to newest, in the order
+// they need to be upgraded. An empty `from` starts at the oldest release and an empty `to` ends at the newest.
+func BuildUpgradeGuide(root *ChangelogYaml, from string, to string) (*DocumentNode, error) {
+	document, err := BuildDocument(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// Releases are sorted with the newest first
+	fromIndex := len(document.Releases)
+	if from != "" {
+		if fromIndex, err = findReleaseIndex(root.Releases, from); err != nil {
+			return nil, err
+		}
+	}
+
+	toIndex := 0
+	if to != "" {
+		if toIndex, err = findReleaseIndex(root.Releases, to); err != nil {
+			return nil, err
+		}
+	}
+
+	if toIndex > fromIndex {
+		return nil, fmt.Errorf("'%s' is older than '%s'", to, from)
+	}
+
+	title := "Upgrade guide"
+	if from != "" {
+		title += fmt.Sprintf(" from %s", from)
+	}
+	if to != "" {
+		title += fmt.Sprintf(" to %s", to)
+	}
+
+	guide := &DocumentNode{Title: title, Repo: document.Repo, URL: document.URL, Categories: document.Categories}
+
+	for index := fromIndex - 1; index >= toIndex; index-- {
+		release := document.Releases[index]
+
+		var groups []GroupNode
+		for _, group := range release.Groups {
+			var entries []EntryNode
+			for _, entry := range group.Entries {
+				if isUpgradeEntry(&entry) {
+					entries = append(entries, entry)
+				}
+			}
+
+			if len(entries) > 0 {
+				group.Entries = entries
+				group.Notice = nil
+				groups = append(groups, group)
+			}
+		}
+
+		if len(groups) > 0 {
+			release.Groups = groups
+			guide.Releases = append(guide.Releases, release)
+		}
+	}
+
+	return guide, nil
+}
+
+func renderMigration(migration *Migration, formatter Formatter, writer io.Writer) error {
+	output := ""
+
+	if migration.Description != "" {
+		output += RenderInlines(parseInlineMarkup(migration.Description), formatter) + "\n\n"
+	}
+
+	if migration.Before != "" {
+		output += formatter.Strong(formatter.Text("Before:")) + "\n\n" + formatter.CodeBlock(migration.Language,
+			migration.Before)
+	}
+
+	if migration.After != "" {
+		output += formatter.Strong(formatter.Text("After:")) + "\n\n" + formatter.CodeBlock(migration.Language,
+			migration.After)
+	}
+
+	_, err := fmt.Fprint(writer, output)
+
+	return err
+}
+
+// RenderUpgradeGuide writes a document built by BuildUpgradeGuide. Every entry is followed by its migration.
+func RenderUpgradeGuide(guide *DocumentNode, formatter Formatter, writer io.Writer) error {
+	if _, err := fmt.Fprint(writer, formatter.Heading(1, formatter.Text(guide.Title))); err != nil {
+		return err
+	}
+
+	if len(guide.Releases) == 0 {
+		_, err := fmt.Fprintf(writer, "%s\n", formatter.Text("There are no breaking changes."))
+		return err
+	}
+
+	for _, release := range guide.Releases {
+		heading := formatter.Anchor(release.ID) +
+			formatter.Heading(2, renderReleaseHeading(&release, formatter, RenderOptions{}))
+		if _, err := fmt.Fprint(writer, heading); err != nil {
+			return err
+		}
+
+		for _, group := range release.Groups {
+			if _, err := fmt.Fprint(writer, formatter.Heading(3, renderGroupHeading(&group, formatter))); err != nil {
+				return err
+			}
+
+			for _, entry := range group.Entries {
+				bulletPoint := formatter.BulletPoint(renderEntry(&entry, guide.Categories, formatter, RenderOptions{}))
+				if _, err := fmt.Fprint(writer, bulletPoint+"\n"); err != nil {
+					return err
+				}
+
+				if entry.Migration != nil {
+					if err := renderMigration(entry.Migration, formatter, writer); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
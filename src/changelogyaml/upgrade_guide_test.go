@@ -0,0 +1,186 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const upgradeGuideTestChangelog = `
+repo: piot/nimble
+releases:
+  - name: v3.0.0
+    date: 2023-06-03
+    sections:
+      main:
+        changes:
+          removed:
+            - Remove the old API
+          fixed:
+            - Fix the crash
+  - name: v2.0.0
+    date: 2023-06-02
+    sections:
+      main:
+        changes:
+          breaking:
+            - text: Rename clog_info()
+              migration:
+                description: Replace **all** calls.
+                language: c
+                before: clog_info("hello");
+                after: clog_information("hello");
+          changed:
+            - text: Change the default level
+              migration:
+                description: Set the level.
+  - name: v1.1.0
+    date: 2023-06-01
+    sections:
+      main:
+        changes:
+          added:
+            - Add a feature
+  - name: v1.0.0
+    date: 2023-05-01
+    sections:
+      main:
+        changes:
+          breaking:
+            - Break everything
+`
+
+func TestBuildUpgradeGuide(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		title    string
+		releases []string
+		entries  []string
+	}{
+		{"all", "", "", "Upgrade guide", []string{"v1.0.0", "v2.0.0", "v3.0.0"},
+			[]string{"Break everything", "Rename clog_info()", "Change the default level", "Remove the old API"}},
+		{"from", "v1.0.0", "", "Upgrade guide from v1.0.0", []string{"v2.0.0", "v3.0.0"},
+			[]string{"Rename clog_info()", "Change the default level", "Remove the old API"}},
+		{"from and to", "v1.0.0", "v2.0.0", "Upgrade guide from v1.0.0 to v2.0.0", []string{"v2.0.0"},
+			[]string{"Rename clog_info()", "Change the default level"}},
+		{"nothing to upgrade", "v1.0.0", "v1.1.0", "Upgrade guide from v1.0.0 to v1.1.0", nil, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := ReadChangelog(bytes.NewBufferString(upgradeGuideTestChangelog), ".")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			guide, err := BuildUpgradeGuide(root, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if guide.Title != test.title {
+				t.Errorf("got title %q, want %q", guide.Title, test.title)
+			}
+
+			var releases []string
+			var entries []string
+			for _, release := range guide.Releases {
+				releases = append(releases, release.Name)
+				for _, group := range release.Groups {
+					for _, entry := range group.Entries {
+						entries = append(entries, entry.Raw)
+					}
+				}
+			}
+
+			if strings.Join(releases, ",") != strings.Join(test.releases, ",") {
+				t.Errorf("got releases %v, want %v", releases, test.releases)
+			}
+			if strings.Join(entries, ",") != strings.Join(test.entries, ",") {
+				t.Errorf("got entries %v, want %v", entries, test.entries)
+			}
+		})
+	}
+}
+
+func TestBuildUpgradeGuideErrors(t *testing.T) {
+	root, err := ReadChangelog(bytes.NewBufferString(upgradeGuideTestChangelog), ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		from string
+		to   string
+		want string
+	}{
+		{"v3.0.0", "v1.0.0", "'v1.0.0' is older than 'v3.0.0'"},
+		{"v0.1.0", "", "v0.1.0"},
+		{"", "v9.0.0", "v9.0.0"},
+	}
+
+	for _, test := range tests {
+		if _, err := BuildUpgradeGuide(root, test.from, test.to); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%q %q: got %v, want an error with %q", test.from, test.to, err, test.want)
+		}
+	}
+}
+
+func TestRenderUpgradeGuide(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      string
+		to        string
+		formatter Formatter
+		want      []string
+	}{
+		{"markdown", "v1.1.0", "v2.0.0", &MarkdownFormatter{}, []string{
+			"# Upgrade guide from v1.1.0 to v2.0.0\n\n",
+			"<a id=\"v2-0-0\"></a>\n\n## :bookmark: [v2.0.0]",
+			"### main\n\n",
+			"* :triangular_flag_on_post:\\[breaking\\] Rename clog\\_info()\n\n",
+			"Replace **all** calls.\n\n",
+			"**Before:**\n\n```c\nclog_info(\"hello\");\n```\n\n",
+			"**After:**\n\n```c\nclog_information(\"hello\");\n```\n\n",
+			"Change the default level\n\nSet the level.\n\n",
+		}},
+		{"asciidoc", "v1.1.0", "v2.0.0", &AsciiDocFormatter{}, []string{
+			"= Upgrade guide from v1.1.0 to v2.0.0\n\n",
+			"[[v2-0-0]]\n== &#x1F516; link:https://github.com/piot/nimble/releases/tag/v2.0.0[v2.0.0]",
+			"* &#x1F6A9;&#91;breaking&#93; Rename clog&#95;info()\n\n",
+			"Replace **all** calls.\n\n",
+			"**Before:**\n\n[source,c]\n----\nclog_info(\"hello\");\n----\n\n",
+		}},
+		{"nothing to upgrade", "v1.0.0", "v1.1.0", &MarkdownFormatter{}, []string{
+			"# Upgrade guide from v1.0.0 to v1.1.0\n\nThere are no breaking changes.\n",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := ReadChangelog(bytes.NewBufferString(upgradeGuideTestChangelog), ".")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			guide, err := BuildUpgradeGuide(root, test.from, test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var output bytes.Buffer
+			if err := RenderUpgradeGuide(guide, test.formatter, &output); err != nil {
+				t.Fatal(err)
+			}
+
+			checkOrder(t, output.String(), test.want)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GitRepository answers the questions that Verify has about a git repository.
+type GitRepository interface {
+	// ResolveCommit returns the full hashes of all commits that start with the prefix.
+	ResolveCommit(prefix string) ([]string, error)
+	// TagDate returns the commit date of the commit that the tag points to. The bool is false if there is no such tag.
+	TagDate(tag string) (time.Time, bool, error)
+}
+
+type VerifyConfig struct {
+	// RepoKey selects which commit hashes that are checked. The hashes in the sections are checked if it is empty,
+	// otherwise the hashes in the repo with this key.
+	RepoKey string
+	// MaxDateDifferenceDays is how many days the commit date of a tag may differ from the release date.
+	MaxDateDifferenceDays int
+}
+
+func DefaultVerifyConfig() VerifyConfig {
+	return VerifyConfig{MaxDateDifferenceDays: 1}
+}
+
+func commitHashes(text string) []string {
+	var hashes []string
+	inlines, _ := applyAutolinker(tokenizeLine(parseInlineMarkup(text)), commitHashAutolinker{}, &LinkContext{})
+	for _, inline := range inlines {
+		if link, isLink := inline.(LinkNode); isLink && link.Kind == CommitHashLink {
+			hashes = append(hashes, link.Name)
+		}
+	}
+
+	return hashes
+}
+
+func verifyCommitHashes(changes []ChangeNode, repository GitRepository, config VerifyConfig) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+
+	for _, change := range changes {
+		if config.RepoKey == "" && change.GroupKind != SectionGroup ||
+			config.RepoKey != "" && (change.GroupKind != RepoGroup || change.GroupKey != config.RepoKey) {
+			continue
+		}
+
+		for _, hash := range commitHashes(change.Text) {
+			diagnostic := Diagnostic{Severity: SeverityError, Line: change.Line, Column: change.Column}
+
+			commits, err := repository.ResolveCommit(hash)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case len(commits) == 0:
+				diagnostic.Message = fmt.Sprintf("commit hash '%s' is not found", hash)
+			case len(commits) > 1:
+				diagnostic.Message = fmt.Sprintf("commit hash '%s' is ambiguous, it matches %d commits", hash,
+					len(commits))
+			default:
+				continue
+			}
+
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	return diagnostics, nil
+}
+
+func verifyTags(root *yaml.Node, repository GitRepository, config VerifyConfig) ([]Diagnostic, error) {
+	var diagnostics []Diagnostic
+
+	releases := mappingValue(root, "releases")
+	if releases == nil || releases.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	for _, release := range releases.Content {
+		name := mappingValue(release, "name")
+
+		// Unreleased changes have no tag yet
+		if name != nil && (&Release{Name: name.Value}).IsUnreleased() {
+			continue
+		}
+
+		if tag := mappingValue(release, "tag"); tag != nil {
+			name = tag
+		}
+
+		if name == nil {
+			continue
+		}
+
+		tagDate, found, err := repository.TagDate(name.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			diagnostics = append(diagnostics, nodeDiagnostic(SeverityError, name,
+				fmt.Sprintf("release '%s' has no tag", name.Value)))
+			continue
+		}
+
+		date := mappingValue(release, "date")
+		if date == nil {
+			continue
+		}
+
+		releaseDate, err := ParseReleaseDate(date.Value)
+		if err != nil {
+			continue
+		}
+
+		days := int(dateOnly(tagDate).Sub(dateOnly(releaseDate)).Hours() / 24)
+		if days > config.MaxDateDifferenceDays || -days > config.MaxDateDifferenceDays {
+			diagnostics = append(diagnostics, nodeDiagnostic(SeverityWarning, date,
+				fmt.Sprintf("release date %s does not match the date of tag '%s' (%s)", date.Value, name.Value,
+					tagDate.Format("2006-01-02"))))
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// Verify checks the changelog against a git repository. Every commit hash must resolve to exactly one commit,
+// every release name (or tag, if set) must be a tag and the commit date of the tag must be close to the release date.
+// The diagnostics are sorted by line and column.
+func Verify(root *yaml.Node, repository GitRepository, config VerifyConfig) ([]Diagnostic, error) {
+	diagnostics, err := verifyCommitHashes(ChangeNodes(root), repository, config)
+	if err != nil {
+		return nil, err
+	}
+
+	tagDiagnostics, err := verifyTags(root, repository, config)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics = append(diagnostics, tagDiagnostics...)
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		if diagnostics[i].Line != diagnostics[j].Line {
+			return diagnostics[i].Line < diagnostics[j].Line
+		}
+		return diagnostics[i].Column < diagnostics[j].Column
+	})
+
+	return diagnostics, nil
+}
@@ -0,0 +1,139 @@
+/*---------------------------------------------------------------------------------------------
+ *  Copyright (c) Peter Bjorklund. All rights reserved.
+ *  Licensed under the MIT License. See LICENSE in the project root for license information.
+ *--------------------------------------------------------------------------------------------*/
+
+package changelogyaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeNode is a single change line together with its position in the YAML file.
+type ChangeNode struct {
+	// ReleaseIndex is the index in ChangelogYaml.Releases.
+	ReleaseIndex int
+	ReleaseName  string
+	// NewerReleases is the number of releases with a date, so not `Unreleased`, that are newer than the release.
+	NewerReleases int
+	// GroupKind tells if GroupKey is a section name or a repo key.
+	GroupKind GroupKind
+	GroupKey  string
+	Category  CategoryType
+	// CategoryKey is the key as written in the YAML file, e.g. `fixed`.
+	CategoryKey string
+	Entry       Entry
+	Text        string
+	Line        int
+	Column      int
+}
+
+// mappingValue returns the value for the key in a YAML mapping node, or nil if it is not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// ParseYamlNode parses the YAML file and returns the root mapping node.
+func ParseYamlNode(data []byte) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("empty changelog")
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: changelog must be a mapping", root.Line)
+	}
+
+	return root, nil
+}
+
+// collectChangeNodes adds a ChangeNode for every line in changes, with the release and group fields from group.
+func collectChangeNodes(group ChangeNode, changes *yaml.Node, result []ChangeNode) []ChangeNode {
+	if changes == nil || changes.Kind != yaml.MappingNode {
+		return result
+	}
+
+	for i := 0; i+1 < len(changes.Content); i += 2 {
+		categoryKey := changes.Content[i].Value
+		category, found := categoryFromKey(categoryKey)
+		if !found || changes.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+
+		for _, line := range changes.Content[i+1].Content {
+			var entry Entry
+			if err := line.Decode(&entry); err != nil {
+				continue
+			}
+
+			change := group
+			change.Category = category
+			change.CategoryKey = categoryKey
+			change.Entry = entry
+			change.Text = entry.Text
+			change.Line = line.Line
+			change.Column = line.Column
+			result = append(result, change)
+		}
+	}
+
+	return result
+}
+
+// ChangeNodes returns every change line in all sections and repos of all releases, in file order.
+func ChangeNodes(root *yaml.Node) []ChangeNode {
+	var result []ChangeNode
+
+	releases := mappingValue(root, "releases")
+	if releases == nil || releases.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	datedReleases := 0
+
+	for releaseIndex, release := range releases.Content {
+		group := ChangeNode{ReleaseIndex: releaseIndex, NewerReleases: datedReleases}
+		if name := mappingValue(release, "name"); name != nil {
+			group.ReleaseName = name.Value
+		}
+
+		if date := mappingValue(release, "date"); date != nil && date.Value != "" {
+			datedReleases++
+		}
+
+		if sections := mappingValue(release, "sections"); sections != nil && sections.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(sections.Content); i += 2 {
+				group.GroupKind = SectionGroup
+				group.GroupKey = sections.Content[i].Value
+				result = collectChangeNodes(group, mappingValue(sections.Content[i+1], "changes"), result)
+			}
+		}
+
+		if repos := mappingValue(release, "repos"); repos != nil && repos.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(repos.Content); i += 2 {
+				group.GroupKind = RepoGroup
+				group.GroupKey = repos.Content[i].Value
+				result = collectChangeNodes(group, repos.Content[i+1], result)
+			}
+		}
+	}
+
+	return result
+}